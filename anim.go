@@ -28,6 +28,19 @@ type SpriteSheet struct {
 	Scale            int
 	OutlineThickness int
 	OutlineColor     color.RGBA
+
+	Regions []SpriteRegion // where each sprite is in Image
+	Names   map[string]int // name -> index in Sprites
+
+	source    image.Image // used by NewLazySpriteSheet until every sprite has been processed
+	remaining int
+}
+
+// SpriteRegion is the area of a single sprite in the image passed to NewSpriteSheet
+type SpriteRegion struct {
+	Name  string // optional
+	Rect  image.Rectangle
+	Pivot *Vector2 // optional, relative to the top left of Rect
 }
 
 // SpriteSheetOptions are the options which are passed to the NewSpriteSheet function
@@ -35,12 +48,18 @@ type SpriteSheetOptions struct {
 	Scale            int
 	OutlineThickness int
 	OutlineColor     color.RGBA
-}
 
-// NewSpriteSheet returns a new SpriteSheet
-func NewSpriteSheet(img *ebiten.Image, origSpriteWidth, origSpriteHeight int, options SpriteSheetOptions) *SpriteSheet {
-	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	Margin           int // pixels around the edge of the image which don't contain any sprites
+	Spacing          int // pixels between each sprite
+	OffsetX, OffsetY int // where the first sprite starts, added to Margin
 
+	// Regions are used instead of slicing the image into a grid, so sprites can be different sizes. SpritesWide is
+	// set to len(Regions) and SpritesHigh to 1
+	Regions []SpriteRegion
+}
+
+// withDefaults returns a copy of the options with the defaults filled in
+func (options SpriteSheetOptions) withDefaults() SpriteSheetOptions {
 	defaultOptions := SpriteSheetOptions{
 		Scale:            1,
 		OutlineThickness: 0,
@@ -54,19 +73,98 @@ func NewSpriteSheet(img *ebiten.Image, origSpriteWidth, origSpriteHeight int, op
 		options.OutlineThickness = defaultOptions.OutlineThickness
 		options.OutlineColor = defaultOptions.OutlineColor
 	}
+	return options
+}
 
+// newSpriteSheetRegions returns a SpriteSheet with its regions set, where each sprite goes in the padded image, and
+// the size of the padded image before it's scaled
+func newSpriteSheetRegions(bounds image.Rectangle, origSpriteWidth, origSpriteHeight int, options SpriteSheetOptions) (*SpriteSheet, []image.Point, int, int) {
+	w, h := bounds.Dx(), bounds.Dy()
 	s := &SpriteSheet{
-		Image:            img,
 		SpriteWidth:      origSpriteWidth,
 		SpriteHeight:     origSpriteHeight,
 		OrigSpriteWidth:  origSpriteWidth,
 		OrigSpriteHeight: origSpriteHeight,
-		SpritesWide:      w / origSpriteWidth,
-		SpritesHigh:      h / origSpriteHeight,
 		Scale:            options.Scale,
 		OutlineThickness: options.OutlineThickness,
 		OutlineColor:     options.OutlineColor,
+		Names:            make(map[string]int),
+	}
+
+	p := 2 + options.OutlineThickness*2
+
+	// find where every sprite is in img and where it goes in the padded image
+	var dests []image.Point
+	var paddedW, paddedH int
+	if len(options.Regions) > 0 {
+		s.Regions = options.Regions
+		s.SpritesWide = len(options.Regions)
+		s.SpritesHigh = 1
+
+		// place the regions in rows
+		rowW := w
+		for _, r := range s.Regions {
+			rowW = maxInt(rowW, r.Rect.Dx()+p*2)
+		}
+		x, y, rowH := p, p, 0
+		for _, r := range s.Regions {
+			if x+r.Rect.Dx()+p > rowW {
+				x = p
+				y += rowH + p
+				rowH = 0
+			}
+			dests = append(dests, image.Pt(x, y))
+			x += r.Rect.Dx() + p
+			rowH = maxInt(rowH, r.Rect.Dy())
+			s.OrigSpriteWidth = maxInt(s.OrigSpriteWidth, r.Rect.Dx())
+			s.OrigSpriteHeight = maxInt(s.OrigSpriteHeight, r.Rect.Dy())
+		}
+		s.SpriteWidth = s.OrigSpriteWidth
+		s.SpriteHeight = s.OrigSpriteHeight
+		paddedW, paddedH = rowW, y+rowH+p
+	} else {
+		ox := options.Margin + options.OffsetX
+		oy := options.Margin + options.OffsetY
+		s.SpritesWide = (w - ox - options.Margin + options.Spacing) / (origSpriteWidth + options.Spacing)
+		s.SpritesHigh = (h - oy - options.Margin + options.Spacing) / (origSpriteHeight + options.Spacing)
+
+		s.Regions = make([]SpriteRegion, s.SpritesWide*s.SpritesHigh)
+		dests = make([]image.Point, s.SpritesWide*s.SpritesHigh)
+		for y := 0; y < s.SpritesHigh; y++ {
+			for x := 0; x < s.SpritesWide; x++ {
+				sx := ox + x*(origSpriteWidth+options.Spacing)
+				sy := oy + y*(origSpriteHeight+options.Spacing)
+				s.Regions[x+y*s.SpritesWide] = SpriteRegion{
+					Rect: image.Rect(sx, sy, sx+origSpriteWidth, sy+origSpriteHeight),
+				}
+				dests[x+y*s.SpritesWide] = image.Pt(
+					origSpriteWidth*x+p*(x+1),
+					origSpriteHeight*y+p*(y+1))
+			}
+		}
+		paddedW = s.SpritesWide*origSpriteWidth + (s.SpritesWide+1)*p
+		paddedH = s.SpritesHigh*origSpriteHeight + (s.SpritesHigh+1)*p
 	}
+	for i, r := range s.Regions {
+		if r.Name != "" {
+			s.Names[r.Name] = i
+		}
+	}
+
+	s.SpriteWidth += options.OutlineThickness
+	s.SpriteHeight += options.OutlineThickness
+	s.SpriteWidth *= options.Scale
+	s.SpriteHeight *= options.Scale
+
+	return s, dests, paddedW, paddedH
+}
+
+// NewSpriteSheet returns a new SpriteSheet
+func NewSpriteSheet(img *ebiten.Image, origSpriteWidth, origSpriteHeight int, options SpriteSheetOptions) *SpriteSheet {
+	options = options.withDefaults()
+	s, dests, paddedW, paddedH := newSpriteSheetRegions(img.Bounds(), origSpriteWidth, origSpriteHeight, options)
+	s.Image = img
+	p := 2 + options.OutlineThickness*2
 
 	// all white copy of image without any opacity which could ruin outline
 	imgWhite := ebiten.NewImage(img.Bounds().Dx(), img.Bounds().Dy())
@@ -77,125 +175,101 @@ func NewSpriteSheet(img *ebiten.Image, origSpriteWidth, origSpriteHeight int, op
 	cm.Translate(1, 1, 1, 0)
 	colorm.DrawImage(imgWhite, img, cm, op)
 
-	p := 2 + options.OutlineThickness*2
-	paddedImg := ebiten.NewImage(
-		(w+(s.SpritesWide+1)*p)*options.Scale,
-		(h+(s.SpritesHigh+1)*p)*options.Scale)
-	outlineImg := ebiten.NewImage(
-		(w+(s.SpritesWide+1)*p)*options.Scale,
-		(h+(s.SpritesHigh+1)*p)*options.Scale)
+	paddedImg := ebiten.NewImage(paddedW*options.Scale, paddedH*options.Scale)
+	outlineImg := ebiten.NewImage(paddedW*options.Scale, paddedH*options.Scale)
 	eraser := ebiten.NewImage(
-		origSpriteWidth+options.OutlineThickness*2,
-		origSpriteHeight+options.OutlineThickness*2)
+		s.OrigSpriteWidth+options.OutlineThickness*2,
+		s.OrigSpriteHeight+options.OutlineThickness*2)
 	eraser.Fill(color.RGBA{255, 255, 255, 255})
 
 	c := options.OutlineColor
-	s.Sprites = make([]*ebiten.Image, s.SpritesWide*s.SpritesHigh)
-	for x := 0; x < s.SpritesWide; x++ {
-		for y := 0; y < s.SpritesHigh; y++ {
-			dx := float64(origSpriteWidth)*float64(x) + float64(p)*(float64(x)+1)
-			dy := float64(origSpriteHeight)*float64(y) + float64(p)*(float64(y)+1)
-
-			// draw padding first
-			d := func(op *ebiten.DrawImageOptions) {
-				paddedImg.DrawImage(img.SubImage(
-					image.Rect(
-						x*s.SpriteWidth,
-						y*s.SpriteHeight,
-						(x+1)*s.SpriteWidth,
-						(y+1)*s.SpriteHeight,
-					)).(*ebiten.Image), op)
-			}
-			for zx := -p / 2; zx <= p/2; zx++ {
-				if zx != 0 {
-					op := &ebiten.DrawImageOptions{}
-					op.GeoM.Translate(dx+float64(zx), dy)
-					op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
-					if options.OutlineThickness > 0 {
-						cm.Scale(0, 0, 0, float64(c.A)/0xff)
-						cm.Translate(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff, 0)
-					}
-					d(op)
+	s.Sprites = make([]*ebiten.Image, len(s.Regions))
+	origin := img.Bounds().Min
+	for i, region := range s.Regions {
+		src := region.Rect
+		sw, sh := src.Dx(), src.Dy()
+		dx := float64(dests[i].X)
+		dy := float64(dests[i].Y)
+		sprite := img.SubImage(src.Add(origin)).(*ebiten.Image)
+
+		// draw padding first
+		d := func(op *ebiten.DrawImageOptions) {
+			paddedImg.DrawImage(sprite, op)
+		}
+		for zx := -p / 2; zx <= p/2; zx++ {
+			if zx != 0 {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(dx+float64(zx), dy)
+				op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
+				if options.OutlineThickness > 0 {
+					cm.Scale(0, 0, 0, float64(c.A)/0xff)
+					cm.Translate(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff, 0)
 				}
+				d(op)
 			}
-			for zy := -p / 2; zy <= p/2; zy++ {
-				if zy != 0 {
-					op := &ebiten.DrawImageOptions{}
-					op.GeoM.Translate(dx, dy+float64(zy))
-					op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
-					if options.OutlineThickness > 0 {
-						cm.Scale(0, 0, 0, float64(c.A)/0xff)
-						cm.Translate(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff, 0)
-					}
-					d(op)
+		}
+		for zy := -p / 2; zy <= p/2; zy++ {
+			if zy != 0 {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(dx, dy+float64(zy))
+				op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
+				if options.OutlineThickness > 0 {
+					cm.Scale(0, 0, 0, float64(c.A)/0xff)
+					cm.Translate(float64(c.R)/0xff, float64(c.G)/0xff, float64(c.B)/0xff, 0)
 				}
+				d(op)
 			}
+		}
 
-			// clear area, if a tile isn't full width, it'll be the wrong size (2px will be increased to 4px wide!)
-			op := &ebiten.DrawImageOptions{}
-			op.GeoM.Translate(dx-float64(options.OutlineThickness), dy-float64(options.OutlineThickness))
-			op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
-			op.Blend = ebiten.BlendClear
-			paddedImg.DrawImage(eraser, op)
-
-			// draw outline to the outlineImg
-			for zy := -options.OutlineThickness; zy <= options.OutlineThickness; zy++ {
-				for zx := -options.OutlineThickness; zx <= options.OutlineThickness; zx++ {
-					op := &ebiten.DrawImageOptions{}
-					op.GeoM.Translate(
-						dx+float64(zx)/float64(options.Scale),
-						dy+float64(zy)/float64(options.Scale))
-					op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
-
-					outlineImg.DrawImage(imgWhite.SubImage(
-						image.Rect(
-							x*s.SpriteWidth,
-							y*s.SpriteHeight,
-							(x+1)*s.SpriteWidth,
-							(y+1)*s.SpriteHeight,
-						)).(*ebiten.Image), op)
-				}
+		// clear area, if a tile isn't full width, it'll be the wrong size (2px will be increased to 4px wide!)
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(dx-float64(options.OutlineThickness), dy-float64(options.OutlineThickness))
+		op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
+		op.Blend = ebiten.BlendClear
+		paddedImg.DrawImage(eraser.SubImage(image.Rect(
+			0, 0,
+			sw+options.OutlineThickness*2,
+			sh+options.OutlineThickness*2)).(*ebiten.Image), op)
+
+		// draw outline to the outlineImg
+		for zy := -options.OutlineThickness; zy <= options.OutlineThickness; zy++ {
+			for zx := -options.OutlineThickness; zx <= options.OutlineThickness; zx++ {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(
+					dx+float64(zx)/float64(options.Scale),
+					dy+float64(zy)/float64(options.Scale))
+				op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
+
+				outlineImg.DrawImage(imgWhite.SubImage(src).(*ebiten.Image), op)
 			}
-
-			// cut out sprite from the outline
-			op = &ebiten.DrawImageOptions{}
-			op.GeoM.Translate(
-				dx, dy)
-			op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
-			cm.Scale(0, 0, 0, 100)
-			cm.Translate(1, 1, 1, 0)
-			op.Blend = ebiten.BlendDestinationOut
-			outlineImg.DrawImage(img.SubImage(
-				image.Rect(
-					x*s.SpriteWidth,
-					y*s.SpriteHeight,
-					(x+1)*s.SpriteWidth,
-					(y+1)*s.SpriteHeight,
-				)).(*ebiten.Image), op)
-
-			// draw the sprite itself
-			op = &ebiten.DrawImageOptions{}
-			op.GeoM.Translate(
-				dx, dy)
-			op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
-			paddedImg.DrawImage(img.SubImage(
-				image.Rect(
-					x*s.SpriteWidth,
-					y*s.SpriteHeight,
-					(x+1)*s.SpriteWidth,
-					(y+1)*s.SpriteHeight,
-				)).(*ebiten.Image), op)
-
-			// save subimage/reference
-			ot := float64(options.OutlineThickness)
-			s.Sprites[x+y*s.SpritesWide] = paddedImg.SubImage(
-				image.Rect(
-					int(dx-ot)*options.Scale,
-					int(dy-ot)*options.Scale,
-					(int(dx)+s.SpriteWidth+int(ot))*options.Scale,
-					(int(dy)+s.SpriteHeight+int(ot))*options.Scale,
-				)).(*ebiten.Image)
 		}
+
+		// cut out sprite from the outline
+		op = &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(
+			dx, dy)
+		op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
+		cm.Scale(0, 0, 0, 100)
+		cm.Translate(1, 1, 1, 0)
+		op.Blend = ebiten.BlendDestinationOut
+		outlineImg.DrawImage(sprite, op)
+
+		// draw the sprite itself
+		op = &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(
+			dx, dy)
+		op.GeoM.Scale(float64(options.Scale), float64(options.Scale))
+		paddedImg.DrawImage(sprite, op)
+
+		// save subimage/reference
+		ot := float64(options.OutlineThickness)
+		s.Sprites[i] = paddedImg.SubImage(
+			image.Rect(
+				int(dx-ot)*options.Scale,
+				int(dy-ot)*options.Scale,
+				(int(dx)+sw+int(ot))*options.Scale,
+				(int(dy)+sh+int(ot))*options.Scale,
+			)).(*ebiten.Image)
 	}
 
 	// draw outlines with the correct color
@@ -205,25 +279,91 @@ func NewSpriteSheet(img *ebiten.Image, origSpriteWidth, origSpriteHeight int, op
 	colorm.DrawImage(paddedImg, outlineImg, cm, op)
 
 	s.PaddedImage = paddedImg
-	s.SpriteWidth += options.OutlineThickness
-	s.SpriteHeight += options.OutlineThickness
-	s.SpriteWidth *= options.Scale
-	s.SpriteHeight *= options.Scale
 
 	eraser.Deallocate()
+	imgWhite.Deallocate()
+	outlineImg.Deallocate()
 
 	return s
 }
 
 // GetSprite returns the sprite at the position x,y in the tilesheet
 func (s *SpriteSheet) GetSprite(x, y int) *ebiten.Image {
-	return s.Sprites[x+y*s.SpritesWide]
+	return s.GetSpriteByIndex(x + y*s.SpritesWide)
+}
+
+// GetSpriteByIndex returns the sprite at index i in Sprites, or nil if it doesn't exist
+func (s *SpriteSheet) GetSpriteByIndex(i int) *ebiten.Image {
+	if i < 0 || i >= len(s.Sprites) {
+		return nil
+	}
+	if s.Sprites[i] == nil && s.source != nil {
+		s.processSprite(i)
+	}
+	return s.Sprites[i]
+}
+
+// GetSpriteByName returns a sprite using the name of its region, or nil if it doesn't exist
+func (s *SpriteSheet) GetSpriteByName(name string) *ebiten.Image {
+	i, ok := s.Names[name]
+	if !ok {
+		return nil
+	}
+	return s.GetSpriteByIndex(i)
+}
+
+// GetSpriteIndex returns the index of a named sprite, or NoSprite if it doesn't exist
+func (s *SpriteSheet) GetSpriteIndex(name string) int {
+	if i, ok := s.Names[name]; ok {
+		return i
+	}
+	return NoSprite
+}
+
+// SetName names the sprite at index i so it can be used with GetSpriteByName, it's ignored if i is out of range
+func (s *SpriteSheet) SetName(name string, i int) *SpriteSheet {
+	if i < 0 || i >= len(s.Regions) {
+		return s
+	}
+	s.Names[name] = i
+	s.Regions[i].Name = name
+	return s
+}
+
+// SetPivot sets the pivot of the sprite at index i, relative to the top left of its region. It's ignored if i is out of
+// range
+func (s *SpriteSheet) SetPivot(i int, pivot *Vector2) *SpriteSheet {
+	if i < 0 || i >= len(s.Regions) {
+		return s
+	}
+	s.Regions[i].Pivot = pivot
+	return s
+}
+
+// GetPivot returns the pivot of the sprite at index i, relative to the top left of the sprite's image, so the outline
+// and Scale are taken into account. The center of the sprite is used if it doesn't have a pivot, nil is returned if i is
+// out of range
+func (s *SpriteSheet) GetPivot(i int) *Vector2 {
+	if i < 0 || i >= len(s.Regions) {
+		return nil
+	}
+	r := s.Regions[i]
+	pivot := NewVector2(float64(r.Rect.Dx())/2, float64(r.Rect.Dy())/2)
+	if r.Pivot != nil {
+		pivot = r.Pivot.Clone()
+	}
+	ot := float64(s.OutlineThickness)
+	return pivot.Add(NewVector2(ot, ot)).Mult(float64(s.Scale))
 }
 
 // Frame stores a single frame of an Animation. It contains an image and how long it should be drawn for
 type Frame struct {
 	Image    *ebiten.Image
 	Duration time.Duration // how long to draw this frame for
+
+	Pivot     *Vector2        // optional, the point of Image which is drawn at the origin of the DrawImageOptions
+	Offset    *Vector2        // optional, moves the frame when it's drawn
+	Colliders []FrameCollider // optional, hitboxes which are relative to the pivot
 }
 
 // NewFrame returns a new Frame
@@ -234,13 +374,120 @@ func NewFrame(image *ebiten.Image, duration time.Duration) Frame {
 	}
 }
 
+// SetPivot sets the pivot and returns the Frame for chaining
+func (f Frame) SetPivot(x, y float64) Frame {
+	f.Pivot = NewVector2(x, y)
+	return f
+}
+
+// SetOffset sets the offset and returns the Frame for chaining
+func (f Frame) SetOffset(x, y float64) Frame {
+	f.Offset = NewVector2(x, y)
+	return f
+}
+
+// AddCollider adds a hitbox and returns the Frame for chaining
+func (f Frame) AddCollider(c FrameCollider) Frame {
+	f.Colliders = append(f.Colliders, c)
+	return f
+}
+
+// GetGeoM returns the GeoM which moves the frame's pivot to the origin, then applies the offset
+func (f Frame) GetGeoM() ebiten.GeoM {
+	var g ebiten.GeoM
+	if f.Pivot != nil {
+		g.Translate(-f.Pivot.X, -f.Pivot.Y)
+	}
+	if f.Offset != nil {
+		g.Translate(f.Offset.X, f.Offset.Y)
+	}
+	return g
+}
+
+// applyGeoM returns a copy of op with the frame's pivot and offset applied, or op if it doesn't have either
+func (f Frame) applyGeoM(op *ebiten.DrawImageOptions) *ebiten.DrawImageOptions {
+	if f.Pivot == nil && f.Offset == nil {
+		return op
+	}
+	frameOp := &ebiten.DrawImageOptions{}
+	if op != nil {
+		*frameOp = *op
+	}
+	frameOp.GeoM = f.GetGeoM()
+	if op != nil {
+		frameOp.GeoM.Concat(op.GeoM)
+	}
+	return frameOp
+}
+
+// NewFrame returns a new Frame using the sprite at index i, with the pivot of its region if it has one. The Frame's Image
+// is nil if i is out of range
+func (s *SpriteSheet) NewFrame(i int, duration time.Duration) Frame {
+	f := NewFrame(s.GetSpriteByIndex(i), duration)
+	if i >= 0 && i < len(s.Regions) && s.Regions[i].Pivot != nil {
+		f.Pivot = s.GetPivot(i)
+	}
+	return f
+}
+
+// TickDuration returns how long a single ebiten tick lasts, depending on ebiten.TPS
+func TickDuration() time.Duration {
+	tps := ebiten.TPS()
+	if tps == ebiten.SyncWithFPS {
+		if fps := ebiten.ActualFPS(); fps > 0 {
+			return time.Duration(float64(time.Second) / fps)
+		}
+	}
+	if tps <= 0 {
+		tps = ebiten.DefaultTPS
+	}
+	return time.Second / time.Duration(tps)
+}
+
+// PlaybackMode specifies the order an Animation plays its Frames in
+type PlaybackMode int8
+
+// Playback modes
+const (
+	PlaybackLoop     PlaybackMode = iota // plays forward forever
+	PlaybackOnce                         // plays forward once, then holds the last frame
+	PlaybackPingPong                     // plays forward then backward forever
+	PlaybackReverse                      // plays backward forever
+	PlaybackLoopN                        // plays forward Loops times, then holds the last frame
+)
+
 // Animation stores a list of Frames and other data regarding timing
 type Animation struct {
 	Frames        []Frame
 	CurrentFrame  int
 	CurrentSprite *ebiten.Image
-	LastFrameTime time.Time
+	Elapsed       time.Duration // how long the current frame has been drawn for
+	Speed         float64       // multiplies the time passed to UpdateDelta, 0 is treated as 1 and < 0 stops it
 	Paused        bool
+
+	// Deprecated: LastFrameTime is only set when the frame changes, use Elapsed instead
+	LastFrameTime time.Time
+
+	Mode     PlaybackMode
+	Loops    int  // how many times PlaybackLoopN plays, PlaybackPingPong and PlaybackReverse also stop after this if > 0
+	Cycles   int  // how many times the animation has played through since it was reset
+	Finished bool // set when the animation stops on its last frame, Reset to play it again
+	step     int  // 1 when playing forward, -1 when playing backward
+	started  bool // set by the first update after a reset, which sends the events of the first frame
+
+	FrameEvents  map[int][]string                            // events which are sent to OnFrameEvent when a frame starts
+	OnFrameEvent func(a *Animation, frame int, event string) // optional
+	OnLoop       func(a *Animation)                          // optional, called every time a cycle completes
+	OnFinished   func(a *Animation)                          // optional, called when the animation finishes
+
+	ColliderParent interface{}      // set as the parent of the shapes added by SyncColliders
+	Colliders      map[string]Shape // shapes which were added by SyncColliders for the current frame, by name
+	colliderFrame  int
+	colliderHash   *SpatialHash
+	colliderScale  float64
+	colliderKeys   []string // key of each collider of the current frame in Colliders
+
+	Recolor *Recolor // optional, recolors the frames when drawing. Its flash counts down each Update while not Paused
 }
 
 // NewAnimation returns a new Animation
@@ -251,25 +498,182 @@ func NewAnimation(frames []Frame) *Animation {
 	return &Animation{
 		Frames:        frames,
 		CurrentSprite: frames[0].Image,
+		Speed:         1,
 		Paused:        false,
+		Mode:          PlaybackLoop,
+		step:          1,
+		FrameEvents:   make(map[int][]string),
 	}
 }
 
-// Update updates
+// Update advances the animation by a single tick, call it once from ebiten's Update
 func (a *Animation) Update() {
-	if a.Paused {
+	a.UpdateDelta(TickDuration())
+}
+
+// UpdateDelta advances the animation by dt multiplied by Speed. Time left over from the current frame is carried over to
+// the next one so that the animation doesn't drift
+func (a *Animation) UpdateDelta(dt time.Duration) {
+	if a.Recolor != nil && !a.Paused {
+		a.Recolor.Update() // the flash counts down with the animation, but not with Speed
+	}
+	speed := a.Speed
+	if speed == 0 {
+		speed = 1
+	}
+	if a.Paused || a.Finished || speed < 0 {
 		return
 	}
 
-	now := time.Now()
-	if (now.Sub(a.LastFrameTime)) > a.Frames[a.CurrentFrame].Duration {
-		a.LastFrameTime = now
-		a.CurrentFrame++
-		if a.CurrentFrame >= len(a.Frames) {
-			a.CurrentFrame = 0
+	var total time.Duration
+	for _, f := range a.Frames {
+		total += f.Duration
+	}
+	if total <= 0 {
+		return
+	}
+
+	if !a.started {
+		a.start()
+	}
+
+	a.Elapsed += time.Duration(float64(dt) * speed)
+	for !a.Finished && a.Elapsed >= a.Frames[a.CurrentFrame].Duration {
+		a.Elapsed -= a.Frames[a.CurrentFrame].Duration
+		a.nextFrame()
+	}
+	a.CurrentSprite = a.Frames[a.CurrentFrame].Image
+}
+
+// start makes sure the animation begins at the right end for its Mode, in case Mode was changed without SetMode, and
+// sends the events of the first frame
+func (a *Animation) start() {
+	a.started = true
+	switch {
+	case a.Mode == PlaybackReverse && a.step != -1:
+		a.CurrentFrame = len(a.Frames) - 1
+		a.step = -1
+	case a.Mode != PlaybackReverse && a.Mode != PlaybackPingPong && a.step == -1:
+		a.CurrentFrame = 0
+		a.step = 1
+	}
+	a.CurrentSprite = a.Frames[a.CurrentFrame].Image
+	a.sendFrameEvents()
+}
+
+// sendFrameEvents calls OnFrameEvent with the events of the current frame
+func (a *Animation) sendFrameEvents() {
+	if a.OnFrameEvent != nil {
+		for _, event := range a.FrameEvents[a.CurrentFrame] {
+			a.OnFrameEvent(a, a.CurrentFrame, event)
+		}
+	}
+}
+
+// nextFrame moves to the next frame depending on the Mode, and calls the callbacks
+func (a *Animation) nextFrame() {
+	switch a.Mode {
+	case PlaybackReverse:
+		a.step = -1
+	case PlaybackPingPong:
+		if a.step == 0 {
+			a.step = 1
+		}
+	default:
+		a.step = 1
+	}
+	last := len(a.Frames) - 1
+	next := a.CurrentFrame + a.step
+
+	if next < 0 || next > last {
+		if a.Mode == PlaybackPingPong {
+			a.step *= -1
+			next = maxInt(0, minInt(last, a.CurrentFrame+a.step))
+			// a cycle is forward and back again
+			if a.step == 1 && a.completeCycle() {
+				return
+			}
+		} else {
+			if a.completeCycle() {
+				return
+			}
+			if a.step > 0 {
+				next = 0
+			} else {
+				next = last
+			}
+		}
+	}
+
+	a.CurrentFrame = next
+	a.LastFrameTime = time.Now()
+	a.sendFrameEvents()
+}
+
+// completeCycle increments Cycles and returns true if the animation finished
+func (a *Animation) completeCycle() bool {
+	a.Cycles++
+	var finished bool
+	switch a.Mode {
+	case PlaybackOnce:
+		finished = true
+	case PlaybackLoopN, PlaybackPingPong, PlaybackReverse:
+		finished = a.Loops > 0 && a.Cycles >= a.Loops
+	}
+
+	if finished {
+		a.Finished = true
+		a.Elapsed = 0
+		if a.OnFinished != nil {
+			a.OnFinished(a)
 		}
-		a.CurrentSprite = a.Frames[a.CurrentFrame].Image
+	} else if a.OnLoop != nil {
+		a.OnLoop(a)
 	}
+	return finished
+}
+
+// SetMode sets the PlaybackMode and resets the animation
+func (a *Animation) SetMode(mode PlaybackMode) *Animation {
+	a.Mode = mode
+	a.Reset()
+	return a
+}
+
+// SetLoops sets how many times the animation plays before it finishes and returns the Animation for chaining
+func (a *Animation) SetLoops(loops int) *Animation {
+	a.Loops = loops
+	return a
+}
+
+// AddFrameEvent adds an event which is sent to OnFrameEvent when the frame starts, e.g. "footstep" on frame 3
+func (a *Animation) AddFrameEvent(frame int, event string) *Animation {
+	if a.FrameEvents == nil {
+		a.FrameEvents = make(map[int][]string)
+	}
+	a.FrameEvents[frame] = append(a.FrameEvents[frame], event)
+	return a
+}
+
+// SetSpeed sets the speed multiplier, 0.5 plays the animation at half speed
+func (a *Animation) SetSpeed(speed float64) *Animation {
+	a.Speed = speed
+	return a
+}
+
+// Reset goes back to the first frame, or the last frame if the Mode is PlaybackReverse
+func (a *Animation) Reset() {
+	a.CurrentFrame = 0
+	a.step = 1
+	if a.Mode == PlaybackReverse {
+		a.CurrentFrame = len(a.Frames) - 1
+		a.step = -1
+	}
+	a.Elapsed = 0
+	a.Cycles = 0
+	a.Finished = false
+	a.started = false
+	a.CurrentSprite = a.Frames[a.CurrentFrame].Image
 }
 
 // GetCurrentSprite returns the current frame from the animation
@@ -277,9 +681,27 @@ func (a *Animation) GetCurrentSprite() *ebiten.Image {
 	return a.Frames[a.CurrentFrame].Image
 }
 
-// Draw draws the animation to the surface with the provided DrawImageOptions
+// GetCurrentFrame returns the current Frame
+func (a *Animation) GetCurrentFrame() Frame {
+	return a.Frames[a.CurrentFrame]
+}
+
+// Draw draws the animation to the surface with the provided DrawImageOptions. The frame's pivot and offset are
+// applied before op.GeoM
 func (a *Animation) Draw(surface *ebiten.Image, op *ebiten.DrawImageOptions) {
-	surface.DrawImage(a.Frames[a.CurrentFrame].Image, op)
+	frame := a.Frames[a.CurrentFrame]
+	op = frame.applyGeoM(op)
+	if a.Recolor != nil {
+		a.Recolor.Draw(surface, frame.Image, op)
+		return
+	}
+	surface.DrawImage(frame.Image, op)
+}
+
+// DrawOutlined draws the current frame to the surface with an outline, see DrawOutlinedWithOptions
+func (a *Animation) DrawOutlined(surface *ebiten.Image, op *ebiten.DrawImageOptions, options OutlineOptions) {
+	frame := a.Frames[a.CurrentFrame]
+	drawOutlined(surface, frame.Image, frame.applyGeoM(op), options, a.Recolor)
 }
 
 // Pause pauses the animation
@@ -0,0 +1,182 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// testFrames returns n frames which are each drawn for d, without images
+func testFrames(n int, d time.Duration) []Frame {
+	frames := make([]Frame, n)
+	for i := range frames {
+		frames[i].Duration = d
+	}
+	return frames
+}
+
+func TestAnimationCarryOver(t *testing.T) {
+	a := NewAnimation(testFrames(3, 100*time.Millisecond))
+
+	// 70ms steps don't line up with the frames, the time left over has to be carried over
+	want := []struct {
+		frame   int
+		elapsed time.Duration
+	}{
+		{0, 70 * time.Millisecond},
+		{1, 40 * time.Millisecond},
+		{2, 10 * time.Millisecond},
+		{2, 80 * time.Millisecond},
+		{0, 50 * time.Millisecond},
+		{1, 20 * time.Millisecond},
+	}
+	for i, w := range want {
+		a.UpdateDelta(70 * time.Millisecond)
+		if a.CurrentFrame != w.frame || a.Elapsed != w.elapsed {
+			t.Fatalf("step %d: frame %d elapsed %v, want frame %d elapsed %v", i, a.CurrentFrame, a.Elapsed, w.frame, w.elapsed)
+		}
+	}
+	if a.Cycles != 1 {
+		t.Errorf("Cycles = %d, want 1", a.Cycles)
+	}
+}
+
+func TestAnimationSkipsFrames(t *testing.T) {
+	a := NewAnimation(testFrames(4, 10*time.Millisecond))
+	a.UpdateDelta(35 * time.Millisecond)
+	if a.CurrentFrame != 3 || a.Elapsed != 5*time.Millisecond {
+		t.Errorf("frame %d elapsed %v, want frame 3 elapsed 5ms", a.CurrentFrame, a.Elapsed)
+	}
+}
+
+func TestAnimationSpeed(t *testing.T) {
+	tests := []struct {
+		name  string
+		speed float64
+		steps int
+		frame int
+	}{
+		{"normal", 1, 3, 1},
+		{"double", 2, 3, 2},
+		{"half", 0.5, 6, 1},
+		{"zero is normal", 0, 3, 1},
+		{"negative stops", -1, 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a struct literal, so nothing is set up by NewAnimation
+			a := &Animation{Frames: testFrames(5, 60*time.Millisecond), Speed: tt.speed}
+			for i := 0; i < tt.steps; i++ {
+				a.UpdateDelta(20 * time.Millisecond)
+			}
+			if a.CurrentFrame != tt.frame {
+				t.Errorf("frame %d, want %d", a.CurrentFrame, tt.frame)
+			}
+		})
+	}
+}
+
+func TestAnimationPaused(t *testing.T) {
+	a := NewAnimation(testFrames(2, 10*time.Millisecond))
+	a.Pause()
+	a.UpdateDelta(time.Second)
+	if a.CurrentFrame != 0 || a.Elapsed != 0 {
+		t.Errorf("paused animation moved to frame %d elapsed %v", a.CurrentFrame, a.Elapsed)
+	}
+}
+
+func TestAnimationPlaybackModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(a *Animation)
+		frames   []int // CurrentFrame after each update
+		cycles   int
+		finished bool
+	}{
+		{"loop", func(a *Animation) {}, []int{1, 2, 0, 1, 2, 0, 1}, 2, false},
+		{"once", func(a *Animation) { a.SetMode(PlaybackOnce) }, []int{1, 2, 2, 2}, 1, true},
+		{"loop n", func(a *Animation) { a.SetMode(PlaybackLoopN).SetLoops(2) }, []int{1, 2, 0, 1, 2, 2, 2}, 2, true},
+		{"reverse", func(a *Animation) { a.SetMode(PlaybackReverse) }, []int{1, 0, 2, 1}, 1, false},
+		{"reverse set directly", func(a *Animation) { a.Mode = PlaybackReverse }, []int{1, 0, 2, 1}, 1, false},
+		{"reverse loops", func(a *Animation) { a.SetMode(PlaybackReverse).SetLoops(1) }, []int{1, 0, 0}, 1, true},
+		{"ping pong", func(a *Animation) { a.SetMode(PlaybackPingPong) }, []int{1, 2, 1, 0, 1, 2, 1}, 1, false},
+		{"ping pong loops", func(a *Animation) { a.SetMode(PlaybackPingPong).SetLoops(1) }, []int{1, 2, 1, 0, 0}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAnimation(testFrames(3, 10*time.Millisecond))
+			tt.setup(a)
+			for i, want := range tt.frames {
+				a.UpdateDelta(10 * time.Millisecond)
+				if a.CurrentFrame != want {
+					t.Fatalf("update %d: frame %d, want %d", i, a.CurrentFrame, want)
+				}
+			}
+			if a.Cycles != tt.cycles || a.Finished != tt.finished {
+				t.Errorf("Cycles %d Finished %v, want %d %v", a.Cycles, a.Finished, tt.cycles, tt.finished)
+			}
+		})
+	}
+}
+
+func TestAnimationFrameEvents(t *testing.T) {
+	var got []string
+	a := NewAnimation(testFrames(3, 10*time.Millisecond))
+	a.AddFrameEvent(0, "start").AddFrameEvent(0, "spawn").AddFrameEvent(2, "end")
+	a.OnFrameEvent = func(a *Animation, frame int, event string) {
+		got = append(got, event)
+	}
+	var loops, finished int
+	a.OnLoop = func(a *Animation) { loops++ }
+	a.OnFinished = func(a *Animation) { finished++ }
+
+	a.UpdateDelta(0)                     // the first frame's events are sent by the first update
+	a.UpdateDelta(20 * time.Millisecond) // frames 1 and 2
+	a.UpdateDelta(10 * time.Millisecond) // back to 0
+	a.Reset()
+	a.UpdateDelta(0) // frame 0 again after the reset
+	a.SetMode(PlaybackOnce)
+	a.UpdateDelta(50 * time.Millisecond)
+
+	want := []string{"start", "spawn", "end", "start", "spawn", "start", "spawn", "start", "spawn", "end"}
+	if len(got) != len(want) {
+		t.Fatalf("got events %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got events %v, want %v", got, want)
+		}
+	}
+	if loops != 1 || finished != 1 {
+		t.Errorf("OnLoop called %d times and OnFinished %d, want 1 and 1", loops, finished)
+	}
+}
+
+func TestSpriteSheetOutOfRange(t *testing.T) {
+	s := &SpriteSheet{
+		Sprites: make([]*ebiten.Image, 2),
+		Regions: []SpriteRegion{{Rect: image.Rect(0, 0, 8, 8)}, {Rect: image.Rect(8, 0, 16, 8)}},
+		Names:   map[string]int{},
+		Scale:   1,
+	}
+	for _, i := range []int{-1, 2} {
+		s.SetName("bad", i).SetPivot(i, NewVector2(1, 1))
+		if _, ok := s.Names["bad"]; ok {
+			t.Fatalf("SetName(%d) should be ignored", i)
+		}
+		if s.GetPivot(i) != nil {
+			t.Fatalf("GetPivot(%d) should be nil", i)
+		}
+		if f := s.NewFrame(i, time.Second); f.Image != nil || f.Pivot != nil {
+			t.Fatalf("NewFrame(%d) should be empty", i)
+		}
+	}
+
+	s.SetName("ok", 1).SetPivot(1, NewVector2(2, 3))
+	if s.Names["ok"] != 1 || *s.GetPivot(1) != *NewVector2(2, 3) {
+		t.Fatalf("in range calls should still work")
+	}
+}
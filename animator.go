@@ -0,0 +1,187 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	// ErrAnimationNotFound is returned when an Animator can't find an Animation by name
+	ErrAnimationNotFound = errors.New("Animation not found")
+)
+
+// AnyState can be used as the From of an AnimatorTransition so it can happen from every state
+const AnyState = "*"
+
+// AnimatorTransition changes the Animator's state from one Animation to another
+type AnimatorTransition struct {
+	From, To string
+
+	Condition     func(a *Animator) bool // optional, the transition only happens if this returns true
+	Trigger       string                 // optional, the transition only happens if the trigger is set, and consumes it
+	Priority      int                    // transitions with a higher priority are checked first
+	WaitForFinish bool                   // wait for the current animation to finish or complete a loop first
+}
+
+// Animator is a state machine which switches between named Animations depending on its parameters
+type Animator struct {
+	Animations  map[string]*Animation
+	Transitions []*AnimatorTransition
+	Current     string // name of the current animation
+
+	Floats   map[string]float64
+	Bools    map[string]bool
+	Triggers map[string]bool
+
+	OnStateChanged func(a *Animator, from, to string) // optional
+}
+
+// NewAnimator returns a new *Animator
+func NewAnimator() *Animator {
+	return &Animator{
+		Animations:  make(map[string]*Animation),
+		Transitions: make([]*AnimatorTransition, 0),
+		Floats:      make(map[string]float64),
+		Bools:       make(map[string]bool),
+		Triggers:    make(map[string]bool),
+	}
+}
+
+// AddAnimation adds a named Animation, the first one added becomes the current one
+func (a *Animator) AddAnimation(name string, anim *Animation) *Animator {
+	a.Animations[name] = anim
+	if a.Current == "" {
+		a.Current = name
+		anim.Reset()
+	}
+	return a
+}
+
+// AddTransition adds a transition and returns the Animator for chaining
+func (a *Animator) AddTransition(t *AnimatorTransition) *Animator {
+	a.Transitions = append(a.Transitions, t)
+	sort.SliceStable(a.Transitions, func(i, j int) bool {
+		return a.Transitions[i].Priority > a.Transitions[j].Priority
+	})
+	return a
+}
+
+// SetFloat sets a float parameter, e.g. "speed"
+func (a *Animator) SetFloat(name string, value float64) *Animator {
+	a.Floats[name] = value
+	return a
+}
+
+// GetFloat returns a float parameter
+func (a *Animator) GetFloat(name string) float64 {
+	return a.Floats[name]
+}
+
+// SetBool sets a bool parameter, e.g. "grounded"
+func (a *Animator) SetBool(name string, value bool) *Animator {
+	a.Bools[name] = value
+	return a
+}
+
+// GetBool returns a bool parameter
+func (a *Animator) GetBool(name string) bool {
+	return a.Bools[name]
+}
+
+// SetTrigger sets a trigger which stays set until a transition uses it, e.g. "attack"
+func (a *Animator) SetTrigger(name string) *Animator {
+	a.Triggers[name] = true
+	return a
+}
+
+// ResetTrigger unsets a trigger
+func (a *Animator) ResetTrigger(name string) *Animator {
+	delete(a.Triggers, name)
+	return a
+}
+
+// Play immediately switches to the named animation and restarts it, ignoring transitions
+func (a *Animator) Play(name string) error {
+	anim, ok := a.Animations[name]
+	if !ok {
+		return ErrAnimationNotFound
+	}
+	from := a.Current
+	// the shapes of the previous animation would stay in the hash as stale hitboxes
+	if prev := a.GetCurrentAnimation(); prev != nil && prev != anim {
+		prev.ClearColliders()
+	}
+	a.Current = name
+	anim.Reset()
+	if a.OnStateChanged != nil {
+		a.OnStateChanged(a, from, name)
+	}
+	return nil
+}
+
+// GetCurrentAnimation returns the current Animation, or nil if there aren't any
+func (a *Animator) GetCurrentAnimation() *Animation {
+	return a.Animations[a.Current]
+}
+
+// GetCurrentSprite returns the current frame of the current animation
+func (a *Animator) GetCurrentSprite() *ebiten.Image {
+	if anim := a.GetCurrentAnimation(); anim != nil {
+		return anim.GetCurrentSprite()
+	}
+	return nil
+}
+
+// Update advances the current animation by a single tick and checks the transitions
+func (a *Animator) Update() {
+	a.UpdateDelta(TickDuration())
+}
+
+// UpdateDelta advances the current animation by dt and checks the transitions
+func (a *Animator) UpdateDelta(dt time.Duration) {
+	anim := a.GetCurrentAnimation()
+	if anim == nil {
+		return
+	}
+	cycles := anim.Cycles
+	anim.UpdateDelta(dt)
+	// transitions which wait for the animation only happen at the end of a cycle, not part way through a later one
+	cycled := anim.Cycles != cycles
+
+	for _, t := range a.Transitions {
+		if t.From != AnyState && t.From != a.Current {
+			continue
+		}
+		if t.To == a.Current {
+			continue
+		}
+		if t.WaitForFinish && !anim.Finished && !cycled {
+			continue
+		}
+		if t.Trigger != "" && !a.Triggers[t.Trigger] {
+			continue
+		}
+		if t.Condition != nil && !t.Condition(a) {
+			continue
+		}
+
+		if err := a.Play(t.To); err != nil {
+			continue
+		}
+		if t.Trigger != "" {
+			a.ResetTrigger(t.Trigger)
+		}
+		return
+	}
+}
+
+// Draw draws the current animation to the surface with the provided DrawImageOptions
+func (a *Animator) Draw(surface *ebiten.Image, op *ebiten.DrawImageOptions) {
+	if anim := a.GetCurrentAnimation(); anim != nil {
+		anim.Draw(surface, op)
+	}
+}
@@ -0,0 +1,269 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	// ErrAsepriteFormat is returned when the Aseprite JSON can't be read
+	ErrAsepriteFormat = errors.New("Unknown Aseprite JSON format")
+	// ErrAsepriteTagNotFound is returned when an Animation is created from a tag which doesn't exist
+	ErrAsepriteTagNotFound = errors.New("Aseprite tag not found")
+)
+
+// Aseprite stores the frames, tags and slices which were exported from Aseprite with File > Export Sprite Sheet
+type Aseprite struct {
+	Image  *ebiten.Image // the exported sprite sheet image
+	Frames []Frame
+	Tags   map[string]AsepriteTag
+	Slices map[string]AsepriteSlice
+
+	// TrimOffsets stores how far each frame was moved when "Trim Cels" was used on export
+	TrimOffsets []image.Point
+}
+
+// AsepriteTag is a named range of frames
+type AsepriteTag struct {
+	Name      string
+	From, To  int
+	Direction string // forward, reverse, pingpong or pingpong_reverse
+	Repeat    int    // 0 repeats forever
+}
+
+// AsepriteSlice is a named region of the sprite sheet image
+type AsepriteSlice struct {
+	Name   string
+	Bounds image.Rectangle // bounds of the first key, relative to the frame
+	Pivot  *Vector2        // nil if the slice doesn't have a pivot
+}
+
+// asepriteRect is how rects are stored in the JSON
+type asepriteRect struct {
+	X, Y, W, H int
+}
+
+func (r asepriteRect) rect() image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+}
+
+// asepriteFrame is how frames are stored in the JSON
+type asepriteFrame struct {
+	Frame            asepriteRect `json:"frame"`
+	Trimmed          bool         `json:"trimmed"`
+	SpriteSourceSize asepriteRect `json:"spriteSourceSize"`
+	Duration         int          `json:"duration"` // milliseconds
+}
+
+// asepriteFile is how the JSON is laid out, Frames is either an array or an object (hash)
+type asepriteFile struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		FrameTags []struct {
+			Name      string `json:"name"`
+			From      int    `json:"from"`
+			To        int    `json:"to"`
+			Direction string `json:"direction"`
+			Repeat    string `json:"repeat"`
+		} `json:"frameTags"`
+		Slices []struct {
+			Name string `json:"name"`
+			Keys []struct {
+				Bounds asepriteRect `json:"bounds"`
+				Pivot  *struct {
+					X, Y float64
+				} `json:"pivot"`
+			} `json:"keys"`
+		} `json:"slices"`
+	} `json:"meta"`
+}
+
+// LoadAseprite reads the JSON which was exported alongside img. Both the array and hash formats are supported
+func LoadAseprite(img *ebiten.Image, data []byte) (*Aseprite, error) {
+	var file asepriteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	frames, err := decodeAsepriteFrames(file.Frames)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Aseprite{
+		Image:       img,
+		Frames:      make([]Frame, len(frames)),
+		Tags:        make(map[string]AsepriteTag),
+		Slices:      make(map[string]AsepriteSlice),
+		TrimOffsets: make([]image.Point, len(frames)),
+	}
+
+	origin := img.Bounds().Min
+	for i, f := range frames {
+		a.Frames[i] = NewFrame(
+			img.SubImage(f.Frame.rect().Add(origin)).(*ebiten.Image),
+			time.Duration(f.Duration)*time.Millisecond)
+		if f.Trimmed {
+			a.TrimOffsets[i] = image.Pt(f.SpriteSourceSize.X, f.SpriteSourceSize.Y)
+			a.Frames[i].Offset = NewVector2(float64(f.SpriteSourceSize.X), float64(f.SpriteSourceSize.Y))
+		}
+	}
+
+	for _, t := range file.Meta.FrameTags {
+		repeat, _ := strconv.Atoi(t.Repeat) // empty in older versions of Aseprite
+		a.Tags[t.Name] = AsepriteTag{
+			Name:      t.Name,
+			From:      t.From,
+			To:        t.To,
+			Direction: t.Direction,
+			Repeat:    repeat,
+		}
+	}
+
+	for _, s := range file.Meta.Slices {
+		if len(s.Keys) == 0 {
+			continue
+		}
+		slice := AsepriteSlice{
+			Name:   s.Name,
+			Bounds: s.Keys[0].Bounds.rect(),
+		}
+		if p := s.Keys[0].Pivot; p != nil {
+			slice.Pivot = NewVector2(p.X, p.Y)
+		}
+		a.Slices[s.Name] = slice
+	}
+
+	return a, nil
+}
+
+// decodeAsepriteFrames decodes the frames in the order they're in the JSON
+func decodeAsepriteFrames(raw json.RawMessage) ([]asepriteFrame, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, ErrAsepriteFormat
+	}
+
+	var frames []asepriteFrame
+	switch raw[0] {
+	case '[':
+		if err := json.Unmarshal(raw, &frames); err != nil {
+			return nil, err
+		}
+	case '{':
+		// maps aren't ordered, so read the keys one at a time
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		for dec.More() {
+			if _, err := dec.Token(); err != nil { // filename
+				return nil, err
+			}
+			var f asepriteFrame
+			if err := dec.Decode(&f); err != nil {
+				return nil, err
+			}
+			frames = append(frames, f)
+		}
+	default:
+		return nil, ErrAsepriteFormat
+	}
+	return frames, nil
+}
+
+// NewAnimation returns a new Animation using the frames and direction of the tag
+func (a *Aseprite) NewAnimation(tag string) (*Animation, error) {
+	t, ok := a.Tags[tag]
+	if !ok {
+		return nil, ErrAsepriteTagNotFound
+	}
+	if t.From < 0 || t.To >= len(a.Frames) || t.From > t.To {
+		return nil, ErrOutOfBounds
+	}
+
+	frames := make([]Frame, 0, t.To-t.From+1)
+	frames = append(frames, a.Frames[t.From:t.To+1]...)
+
+	anim := NewAnimation(frames)
+	anim.Loops = t.Repeat
+	switch t.Direction {
+	case "reverse":
+		anim.SetMode(PlaybackReverse)
+	case "pingpong":
+		anim.SetMode(PlaybackPingPong)
+	case "pingpong_reverse":
+		for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+			frames[i], frames[j] = frames[j], frames[i]
+		}
+		anim.SetMode(PlaybackPingPong)
+	default:
+		if t.Repeat > 0 {
+			anim.SetMode(PlaybackLoopN)
+		}
+	}
+	return anim, nil
+}
+
+// NewSpriteSheet returns a new SpriteSheet with a region for each frame, followed by a named region for each slice in
+// the first frame sorted by name. Slice pivots are used as the pivots of their regions. Trimmed frames keep their
+// trimmed size, use Frames or NewAnimation if the trim offsets are needed
+func (a *Aseprite) NewSpriteSheet(options SpriteSheetOptions) (*SpriteSheet, error) {
+	if len(a.Frames) == 0 {
+		return nil, ErrAsepriteFormat
+	}
+	options.Regions = a.spriteRegions()
+	return NewSpriteSheet(a.Image, 0, 0, options), nil
+}
+
+// spriteRegions returns the regions used by NewSpriteSheet, relative to the top left of Image
+func (a *Aseprite) spriteRegions() []SpriteRegion {
+	origin := a.Image.Bounds().Min
+	regions := make([]SpriteRegion, 0, len(a.Frames)+len(a.Slices))
+	for _, f := range a.Frames {
+		regions = append(regions, SpriteRegion{Rect: f.Image.Bounds().Sub(origin)})
+	}
+
+	names := make([]string, 0, len(a.Slices))
+	for name := range a.Slices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		s := a.Slices[name]
+		r := SpriteRegion{
+			Name: name,
+			Rect: s.Bounds.Add(a.Frames[0].Image.Bounds().Min).Sub(a.TrimOffsets[0]).Sub(origin),
+		}
+		if s.Pivot != nil {
+			r.Pivot = s.Pivot.Clone()
+		}
+		regions = append(regions, r)
+	}
+	return regions
+}
+
+// GetSlice returns the region of the slice in the first frame, or nil if the slice doesn't exist
+func (a *Aseprite) GetSlice(name string) *ebiten.Image {
+	return a.GetSliceInFrame(name, 0)
+}
+
+// GetSliceInFrame returns the region of the slice in the frame, or nil if the slice or frame doesn't exist
+func (a *Aseprite) GetSliceInFrame(name string, frame int) *ebiten.Image {
+	s, ok := a.Slices[name]
+	if !ok || frame < 0 || frame >= len(a.Frames) {
+		return nil
+	}
+	// slice bounds are relative to the untrimmed frame
+	img := a.Frames[frame].Image
+	r := s.Bounds.Add(img.Bounds().Min).Sub(a.TrimOffsets[frame])
+	return img.SubImage(r).(*ebiten.Image)
+}
@@ -0,0 +1,51 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const testAsepriteJSON = `{
+	"frames": {
+		"walk 0.aseprite": {"frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "duration": 100},
+		"walk 1.aseprite": {"frame": {"x": 16, "y": 0, "w": 12, "h": 14}, "trimmed": true,
+			"spriteSourceSize": {"x": 2, "y": 1, "w": 12, "h": 14}, "duration": 100}
+	},
+	"meta": {
+		"slices": [
+			{"name": "hand", "keys": [{"bounds": {"x": 10, "y": 8, "w": 4, "h": 4}, "pivot": {"x": 1, "y": 2}}]},
+			{"name": "head", "keys": [{"bounds": {"x": 4, "y": 0, "w": 8, "h": 6}}]}
+		]
+	}
+}`
+
+func TestAsepriteSpriteRegions(t *testing.T) {
+	a, err := LoadAseprite(ebiten.NewImage(32, 16), []byte(testAsepriteJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	regions := a.spriteRegions()
+	want := []SpriteRegion{
+		{Rect: image.Rect(0, 0, 16, 16)},
+		{Rect: image.Rect(16, 0, 28, 14)},
+		{Name: "hand", Rect: image.Rect(10, 8, 14, 12), Pivot: NewVector2(1, 2)},
+		{Name: "head", Rect: image.Rect(4, 0, 12, 6)},
+	}
+	if len(regions) != len(want) {
+		t.Fatalf("got %d regions, want %d", len(regions), len(want))
+	}
+	for i, r := range regions {
+		w := want[i]
+		if r.Name != w.Name || r.Rect != w.Rect || (r.Pivot == nil) != (w.Pivot == nil) ||
+			(r.Pivot != nil && *r.Pivot != *w.Pivot) {
+			t.Fatalf("region %d: got %+v, want %+v", i, r, w)
+		}
+	}
+
+	if _, err := (&Aseprite{}).NewSpriteSheet(SpriteSheetOptions{}); err != ErrAsepriteFormat {
+		t.Fatalf("got %v, want ErrAsepriteFormat", err)
+	}
+}
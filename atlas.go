@@ -0,0 +1,239 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var (
+	// ErrAtlasSpriteTooBig is returned when a sprite doesn't fit on an empty page
+	ErrAtlasSpriteTooBig = errors.New("Sprite is too big to fit in an atlas page")
+)
+
+// AtlasOptions are the options which are passed to the NewAtlas function
+type AtlasOptions struct {
+	PageWidth, PageHeight int // how big each page is, 2048 by default
+	Padding               int // transparent pixels between sprites
+	Extrude               int // how many times the edge pixels of each sprite are repeated to prevent bleeding
+}
+
+// AtlasRegion stores where a sprite was packed
+type AtlasRegion struct {
+	Page int
+	Rect image.Rectangle // excludes extrusion and padding
+}
+
+// Atlas packs many images into a few large pages so that fewer textures are switched between when drawing.
+// Add images and SpriteSheets, then call Pack and use GetSprite
+type Atlas struct {
+	Pages   []*ebiten.Image
+	Sprites map[string]*ebiten.Image
+	Regions map[string]AtlasRegion
+
+	options AtlasOptions
+	sources map[string]*ebiten.Image
+	order   []string // names in the order they were added
+}
+
+// NewAtlas returns a new *Atlas
+func NewAtlas(options AtlasOptions) *Atlas {
+	if options.PageWidth == 0 {
+		options.PageWidth = 2048
+	}
+	if options.PageHeight == 0 {
+		options.PageHeight = 2048
+	}
+	return &Atlas{
+		Sprites: make(map[string]*ebiten.Image),
+		Regions: make(map[string]AtlasRegion),
+		options: options,
+		sources: make(map[string]*ebiten.Image),
+	}
+}
+
+// Add adds an image to be packed, images can be any size
+func (a *Atlas) Add(name string, img *ebiten.Image) *Atlas {
+	if _, ok := a.sources[name]; !ok {
+		a.order = append(a.order, name)
+	}
+	a.sources[name] = img
+	return a
+}
+
+// AddSpriteSheet adds every sprite in the SpriteSheet to be packed, named prefix + "_" + the sprite's index
+func (a *Atlas) AddSpriteSheet(prefix string, s *SpriteSheet) *Atlas {
+	for i := range s.Sprites {
+		a.Add(fmt.Sprintf("%s_%d", prefix, i), s.GetSpriteByIndex(i))
+	}
+	return a
+}
+
+// GetSprite returns a packed sprite, or nil if it doesn't exist or Pack hasn't been called yet
+func (a *Atlas) GetSprite(name string) *ebiten.Image {
+	return a.Sprites[name]
+}
+
+// Deallocate deallocates the pages
+func (a *Atlas) Deallocate() {
+	for _, p := range a.Pages {
+		p.Deallocate()
+	}
+	a.Pages = nil
+	a.Sprites = make(map[string]*ebiten.Image)
+	a.Regions = make(map[string]AtlasRegion)
+}
+
+// Pack packs every image which was added into pages using MaxRects. Previous pages are deallocated, so sprites
+// returned by GetSprite before calling Pack again shouldn't be used
+func (a *Atlas) Pack() error {
+	e, p := a.options.Extrude, a.options.Padding
+	pw, ph := a.options.PageWidth, a.options.PageHeight
+
+	// biggest sprites first pack better
+	names := make([]string, len(a.order))
+	copy(names, a.order)
+	sort.SliceStable(names, func(i, j int) bool {
+		bi, bj := a.sources[names[i]].Bounds(), a.sources[names[j]].Bounds()
+		return maxInt(bi.Dx(), bi.Dy()) > maxInt(bj.Dx(), bj.Dy())
+	})
+
+	bins := make([]*maxRectsBin, 0)
+	regions := make(map[string]AtlasRegion)
+	for _, name := range names {
+		b := a.sources[name].Bounds()
+		w, h := b.Dx()+e*2+p, b.Dy()+e*2+p
+		if w > pw || h > ph {
+			return ErrAtlasSpriteTooBig
+		}
+
+		placed := false
+		for i, bin := range bins {
+			if r, ok := bin.insert(w, h); ok {
+				regions[name] = AtlasRegion{Page: i, Rect: image.Rect(r.Min.X+e, r.Min.Y+e, r.Min.X+e+b.Dx(), r.Min.Y+e+b.Dy())}
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			bin := newMaxRectsBin(pw, ph)
+			r, _ := bin.insert(w, h)
+			regions[name] = AtlasRegion{Page: len(bins), Rect: image.Rect(r.Min.X+e, r.Min.Y+e, r.Min.X+e+b.Dx(), r.Min.Y+e+b.Dy())}
+			bins = append(bins, bin)
+		}
+	}
+
+	a.Deallocate()
+	for range bins {
+		a.Pages = append(a.Pages, ebiten.NewImage(pw, ph))
+	}
+	for name, region := range regions {
+		page := a.Pages[region.Page]
+		a.drawExtruded(page, a.sources[name], region.Rect)
+		a.Sprites[name] = page.SubImage(region.Rect).(*ebiten.Image)
+	}
+	a.Regions = regions
+	return nil
+}
+
+// drawExtruded draws img into r on the page, then repeats its edge pixels Extrude times around it
+func (a *Atlas) drawExtruded(page, img *ebiten.Image, r image.Rectangle) {
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Translate(float64(r.Min.X), float64(r.Min.Y))
+	page.DrawImage(img, op)
+
+	e := a.options.Extrude
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if e <= 0 || w == 0 || h == 0 {
+		return
+	}
+	// src is relative to img, dst is relative to r
+	stretch := func(src image.Rectangle, dx, dy, sx, sy float64) {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Scale(sx, sy)
+		op.GeoM.Translate(float64(r.Min.X)+dx, float64(r.Min.Y)+dy)
+		page.DrawImage(img.SubImage(src.Add(b.Min)).(*ebiten.Image), op)
+	}
+	fe := float64(e)
+	// edges
+	stretch(image.Rect(0, 0, 1, h), -fe, 0, fe, 1)
+	stretch(image.Rect(w-1, 0, w, h), float64(w), 0, fe, 1)
+	stretch(image.Rect(0, 0, w, 1), 0, -fe, 1, fe)
+	stretch(image.Rect(0, h-1, w, h), 0, float64(h), 1, fe)
+	// corners
+	stretch(image.Rect(0, 0, 1, 1), -fe, -fe, fe, fe)
+	stretch(image.Rect(w-1, 0, w, 1), float64(w), -fe, fe, fe)
+	stretch(image.Rect(0, h-1, 1, h), -fe, float64(h), fe, fe)
+	stretch(image.Rect(w-1, h-1, w, h), float64(w), float64(h), fe, fe)
+}
+
+// maxRectsBin keeps track of the free space in a page
+type maxRectsBin struct {
+	free []image.Rectangle
+}
+
+func newMaxRectsBin(w, h int) *maxRectsBin {
+	return &maxRectsBin{free: []image.Rectangle{image.Rect(0, 0, w, h)}}
+}
+
+// insert finds space for a w*h rect using the best short side fit heuristic
+func (b *maxRectsBin) insert(w, h int) (image.Rectangle, bool) {
+	bestShort, bestLong := -1, -1
+	var best image.Rectangle
+	for _, f := range b.free {
+		if f.Dx() < w || f.Dy() < h {
+			continue
+		}
+		short := minInt(f.Dx()-w, f.Dy()-h)
+		long := maxInt(f.Dx()-w, f.Dy()-h)
+		if bestShort == -1 || short < bestShort || (short == bestShort && long < bestLong) {
+			bestShort, bestLong = short, long
+			best = image.Rect(f.Min.X, f.Min.Y, f.Min.X+w, f.Min.Y+h)
+		}
+	}
+	if bestShort == -1 {
+		return image.Rectangle{}, false
+	}
+
+	// split every free rect which overlaps the placed rect into up to 4 smaller ones
+	free := make([]image.Rectangle, 0, len(b.free)+4)
+	for _, f := range b.free {
+		if !f.Overlaps(best) {
+			free = append(free, f)
+			continue
+		}
+		if best.Min.X > f.Min.X {
+			free = append(free, image.Rect(f.Min.X, f.Min.Y, best.Min.X, f.Max.Y))
+		}
+		if best.Max.X < f.Max.X {
+			free = append(free, image.Rect(best.Max.X, f.Min.Y, f.Max.X, f.Max.Y))
+		}
+		if best.Min.Y > f.Min.Y {
+			free = append(free, image.Rect(f.Min.X, f.Min.Y, f.Max.X, best.Min.Y))
+		}
+		if best.Max.Y < f.Max.Y {
+			free = append(free, image.Rect(f.Min.X, best.Max.Y, f.Max.X, f.Max.Y))
+		}
+	}
+
+	// remove free rects which are inside of other ones
+	b.free = make([]image.Rectangle, 0, len(free))
+	for i, f := range free {
+		contained := false
+		for j, o := range free {
+			if i != j && f.In(o) && (f != o || i > j) {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			b.free = append(b.free, f)
+		}
+	}
+	return best, true
+}
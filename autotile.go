@@ -0,0 +1,285 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Neighbor bits used to build the masks returned by NeighborMask4 and NeighborMask8.
+// The first 4 bits are the edges, so a 4-bit mask is always in the range 0-15
+const (
+	NeighborNorth uint8 = 1 << iota
+	NeighborEast
+	NeighborSouth
+	NeighborWest
+	NeighborNorthEast
+	NeighborSouthEast
+	NeighborSouthWest
+	NeighborNorthWest
+)
+
+// AutotileMode specifies how many neighbors are checked when autotiling
+type AutotileMode int8
+
+// Modes
+const (
+	AutotileMode4Bit AutotileMode = iota // 16 tiles, only edges are checked
+	AutotileMode8Bit                     // 47 tiles (blob), corners are checked if both of their edges are set
+)
+
+// NoSprite is used in a TileLayer when nothing should be drawn
+const NoSprite = -1
+
+// AutotileRule maps the neighbor masks of a single Tile onto sprite indices in a SpriteSheet
+type AutotileRule struct {
+	Connects []Tile        // tiles which count as a neighbor, the tile itself is used if empty
+	Sprites  map[uint8]int // mask -> index in SpriteSheet.Sprites
+	Default  int           // used when the mask isn't in Sprites, can be NoSprite
+}
+
+// NewAutotileRule returns a new *AutotileRule which maps masks onto consecutive sprites starting at firstSprite.
+// Use it with Wang16Masks or Blob47Masks if the sprites are laid out in the same order
+func NewAutotileRule(connects []Tile, masks []uint8, firstSprite int) *AutotileRule {
+	r := &AutotileRule{
+		Connects: connects,
+		Sprites:  make(map[uint8]int),
+		Default:  NoSprite,
+	}
+	for i, m := range masks {
+		r.Sprites[m] = firstSprite + i
+	}
+	return r
+}
+
+// connects checks if other counts as a neighbor of t
+func (r *AutotileRule) connects(t, other Tile) bool {
+	if len(r.Connects) == 0 {
+		return t == other
+	}
+	for _, c := range r.Connects {
+		if c == other {
+			return true
+		}
+	}
+	return false
+}
+
+// Autotiler picks sprites for each tile in a World depending on its neighbors
+type Autotiler struct {
+	Mode               AutotileMode
+	Rules              map[Tile]*AutotileRule // tiles without a rule are drawn with NoSprite
+	ConnectOutOfBounds bool                   // treat tiles outside of the world as neighbors
+	Layer              string                 // which layer of the World to read, the active layer is used if empty
+}
+
+// NewAutotiler returns a new *Autotiler
+func NewAutotiler(mode AutotileMode) *Autotiler {
+	return &Autotiler{
+		Mode:               mode,
+		Rules:              make(map[Tile]*AutotileRule),
+		ConnectOutOfBounds: true,
+	}
+}
+
+// SetRule sets the rule used for t and returns the Autotiler for chaining
+func (a *Autotiler) SetRule(t Tile, rule *AutotileRule) *Autotiler {
+	a.Rules[t] = rule
+	return a
+}
+
+// Autotile returns a TileLayer with a sprite index for every tile in the world
+func (a *Autotiler) Autotile(world *World, spriteSheet *SpriteSheet) *TileLayer {
+	layer := NewTileLayer(world.Width, world.Height, spriteSheet)
+	for y := 0; y < world.Height; y++ {
+		for x := 0; x < world.Width; x++ {
+			layer.Sprites[y][x] = a.GetSpriteIndex(world, x, y)
+		}
+	}
+	return layer
+}
+
+// tiles returns the tiles of the layer being autotiled
+func (a *Autotiler) tiles(world *World) [][]Tile {
+	if a.Layer == "" {
+		return world.Tiles
+	}
+	tiles, _ := world.GetLayer(a.Layer)
+	return tiles
+}
+
+// GetSpriteIndex returns the sprite index for the tile at x,y, or NoSprite if the tile has no rule
+func (a *Autotiler) GetSpriteIndex(world *World, x, y int) int {
+	tiles := a.tiles(world)
+	if tiles == nil || x < 0 || x >= world.Width || y < 0 || y >= world.Height {
+		return NoSprite
+	}
+	t := tiles[y][x]
+	rule, ok := a.Rules[t]
+	if !ok {
+		return NoSprite
+	}
+
+	match := func(other Tile) bool {
+		return rule.connects(t, other)
+	}
+	var mask uint8
+	switch a.Mode {
+	case AutotileMode4Bit:
+		mask = world.neighborMask(tiles, x, y, match, a.ConnectOutOfBounds) & 0x0f
+	case AutotileMode8Bit:
+		mask = reduceBlobMask(world.neighborMask(tiles, x, y, match, a.ConnectOutOfBounds))
+	}
+
+	if i, ok := rule.Sprites[mask]; ok {
+		return i
+	}
+	return rule.Default
+}
+
+// NeighborMask4 returns a mask of the edges around x,y in the active layer which satisfy match
+func (world *World) NeighborMask4(x, y int, match func(Tile) bool) uint8 {
+	return world.neighborMask(world.Tiles, x, y, match, false) & 0x0f
+}
+
+// NeighborMask8 returns a blob mask of the edges and corners around x,y in the active layer which satisfy match.
+// Corners are only set if both of their edges are set, so there are only 47 possible masks
+func (world *World) NeighborMask8(x, y int, match func(Tile) bool) uint8 {
+	return reduceBlobMask(world.neighborMask(world.Tiles, x, y, match, false))
+}
+
+// neighborMask returns the unreduced 8-bit mask around x,y. The border is ignored, unlike GetTile
+func (world *World) neighborMask(tiles [][]Tile, x, y int, match func(Tile) bool, outOfBounds bool) uint8 {
+	check := func(x, y int) bool {
+		if x < 0 || x >= world.Width || y < 0 || y >= world.Height {
+			return outOfBounds
+		}
+		return match(tiles[y][x])
+	}
+
+	var mask uint8
+	if check(x, y-1) {
+		mask |= NeighborNorth
+	}
+	if check(x+1, y) {
+		mask |= NeighborEast
+	}
+	if check(x, y+1) {
+		mask |= NeighborSouth
+	}
+	if check(x-1, y) {
+		mask |= NeighborWest
+	}
+	if check(x+1, y-1) {
+		mask |= NeighborNorthEast
+	}
+	if check(x+1, y+1) {
+		mask |= NeighborSouthEast
+	}
+	if check(x-1, y+1) {
+		mask |= NeighborSouthWest
+	}
+	if check(x-1, y-1) {
+		mask |= NeighborNorthWest
+	}
+	return mask
+}
+
+// reduceBlobMask removes corners which don't have both of their edges set
+func reduceBlobMask(mask uint8) uint8 {
+	corner := func(c, e1, e2 uint8) {
+		if mask&e1 == 0 || mask&e2 == 0 {
+			mask &^= c
+		}
+	}
+	corner(NeighborNorthEast, NeighborNorth, NeighborEast)
+	corner(NeighborSouthEast, NeighborSouth, NeighborEast)
+	corner(NeighborSouthWest, NeighborSouth, NeighborWest)
+	corner(NeighborNorthWest, NeighborNorth, NeighborWest)
+	return mask
+}
+
+// Wang16Masks returns all 16 4-bit masks in ascending order
+func Wang16Masks() []uint8 {
+	masks := make([]uint8, 16)
+	for i := range masks {
+		masks[i] = uint8(i)
+	}
+	return masks
+}
+
+// Blob47Masks returns all 47 8-bit blob masks in ascending order
+func Blob47Masks() []uint8 {
+	masks := make([]uint8, 0, 47)
+	for i := 0; i < 256; i++ {
+		if reduceBlobMask(uint8(i)) == uint8(i) {
+			masks = append(masks, uint8(i))
+		}
+	}
+	return masks
+}
+
+// TileLayer stores a sprite index for every tile, ready to be drawn with a SpriteSheet
+type TileLayer struct {
+	Width, Height int
+
+	Sprites     [][]int // indexed [y][x], NoSprite if empty
+	SpriteSheet *SpriteSheet
+}
+
+// NewTileLayer returns a new *TileLayer filled with NoSprite
+func NewTileLayer(width, height int, spriteSheet *SpriteSheet) *TileLayer {
+	sprites := make([][]int, height)
+	for y := range sprites {
+		sprites[y] = make([]int, width)
+		for x := range sprites[y] {
+			sprites[y][x] = NoSprite
+		}
+	}
+	return &TileLayer{
+		Width:       width,
+		Height:      height,
+		Sprites:     sprites,
+		SpriteSheet: spriteSheet,
+	}
+}
+
+// GetSprite returns the sprite index at x,y
+func (l *TileLayer) GetSprite(x, y int) (int, error) {
+	if x < 0 || x >= l.Width || y < 0 || y >= l.Height {
+		return NoSprite, ErrOutOfBounds
+	}
+	return l.Sprites[y][x], nil
+}
+
+// SetSprite sets the sprite index at x,y
+func (l *TileLayer) SetSprite(x, y, i int) error {
+	if x < 0 || x >= l.Width || y < 0 || y >= l.Height {
+		return ErrOutOfBounds
+	}
+	l.Sprites[y][x] = i
+	return nil
+}
+
+// Draw draws every tile to the surface, op is applied after each tile is translated into place and can be nil
+func (l *TileLayer) Draw(surface *ebiten.Image, op *ebiten.DrawImageOptions) {
+	w := float64(l.SpriteSheet.OrigSpriteWidth * l.SpriteSheet.Scale)
+	h := float64(l.SpriteSheet.OrigSpriteHeight * l.SpriteSheet.Scale)
+	ot := float64(l.SpriteSheet.OutlineThickness * l.SpriteSheet.Scale)
+	for y := 0; y < l.Height; y++ {
+		for x := 0; x < l.Width; x++ {
+			i := l.Sprites[y][x]
+			if i == NoSprite {
+				continue
+			}
+			tileOp := &ebiten.DrawImageOptions{}
+			tileOp.GeoM.Translate(float64(x)*w-ot, float64(y)*h-ot)
+			if op != nil {
+				tileOp.GeoM.Concat(op.GeoM)
+				tileOp.ColorScale = op.ColorScale
+				tileOp.Filter = op.Filter
+				tileOp.Blend = op.Blend
+			}
+			surface.DrawImage(l.SpriteSheet.GetSpriteByIndex(i), tileOp)
+		}
+	}
+}
@@ -2,7 +2,9 @@
 package zen
 
 import (
+	"image"
 	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -13,27 +15,40 @@ type Camera struct {
 	Scale          float64
 	Position       *Vector2
 	Width, Height  int
-	Surface        *ebiten.Image
+	Surface        *ebiten.Image // Width*Height part of a bigger image which is only reallocated when it grows
+
+	ViewportX, ViewportY int // where the camera is drawn on the screen by Blit, see SetViewport
+
+	MinZoom, MaxZoom float64 // limits for Scale, MinZoom is treated as 0.01 if it's 0 and MaxZoom is ignored if it's 0
 
 	WorldRotation float64 // used by renderisometric to rotate sprites around a point
+
+	Following *CameraFollow    // optional, set by Follow and used by Update
+	Parallax  []*ParallaxLayer // drawn in order behind the Surface by Blit, see AddParallaxLayer
+	Shake     CameraShake      // see AddTrauma and Punch, updated by Update and applied by Blit
+
+	bounded bool
+	bounds  [4]float64 // x1, y1, x2, y2 in world coords
+
+	backing *ebiten.Image       // Surface is a sub image of this
+	zooming *cameraZoom         // set by ZoomTo
+	pixel   *cameraPixelPerfect // set by SetPixelPerfect
 }
 
 // NewCamera returns a new Camera
 func NewCamera(width, height int, x, y, rotation, zoom float64) *Camera {
-	return &Camera{
+	c := &Camera{
 		Position:       NewVector2(x, y),
-		Width:          width,
-		Height:         height,
 		ScreenRotation: rotation,
-		Scale:          zoom,
-		Surface:        ebiten.NewImage(width, height),
 		WorldRotation:  0,
 	}
+	c.SetZoom(zoom)
+	return c.Resize(width, height)
 }
 
 // Deallocate deallocates the Surface
 func (c *Camera) Deallocate() *Camera {
-	c.Surface.Deallocate()
+	c.backing.Deallocate()
 	return c
 }
 
@@ -41,7 +56,7 @@ func (c *Camera) Deallocate() *Camera {
 func (c *Camera) SetPosition(x, y float64) *Camera {
 	c.Position.X = x
 	c.Position.Y = y
-	return c
+	return c.ClampToBounds()
 }
 
 // MovePosition moves the Camera by x and y.
@@ -49,7 +64,7 @@ func (c *Camera) SetPosition(x, y float64) *Camera {
 func (c *Camera) MovePosition(x, y float64) *Camera {
 	c.Position.X += x
 	c.Position.Y += y
-	return c
+	return c.ClampToBounds()
 }
 
 // GetPosition returns the Camera's Position Vector
@@ -60,13 +75,13 @@ func (c *Camera) GetPosition() *Vector2 {
 // RotateScreen rotates by phi
 func (c *Camera) RotateScreen(phi float64) *Camera {
 	c.ScreenRotation += phi
-	return c
+	return c.ClampToBounds()
 }
 
 // SetScreenRotation sets the rotation to rot
 func (c *Camera) SetScreenRotation(rot float64) *Camera {
 	c.ScreenRotation = rot
-	return c
+	return c.ClampToBounds()
 }
 
 // RotateWorld rotates by phi
@@ -83,44 +98,89 @@ func (c *Camera) SetWorldRotation(rot float64) *Camera {
 
 // Zoom *= the current zoom
 func (c *Camera) Zoom(mul float64) *Camera {
-	c.Scale *= mul
-	if c.Scale <= 0.01 {
-		c.Scale = 0.01
-	}
-	// TODO don't resize canvas
-	c.Resize(c.Width, c.Height)
-	return c
+	return c.SetZoom(c.Scale * mul)
 }
 
-// SetZoom sets the zoom
+// SetZoom sets the zoom, limited by MinZoom and MaxZoom. The zoom is applied by GetTranslation so the Surface isn't
+// resized
 func (c *Camera) SetZoom(zoom float64) *Camera {
-	c.Scale = zoom
-	if c.Scale <= 0.01 {
-		c.Scale = 0.01
+	minZoom := c.MinZoom
+	if minZoom <= 0 {
+		minZoom = 0.01
 	}
-	c.Resize(c.Width, c.Height)
-	return c
+	c.Scale = math.Max(zoom, minZoom)
+	if c.MaxZoom > 0 {
+		c.Scale = math.Min(c.Scale, c.MaxZoom)
+	}
+	return c.ClampToBounds()
 }
 
-// Resize resizes the camera Surface
+// Resize resizes the camera Surface. The image behind it is only reallocated when it needs to grow. In pixel perfect
+// mode the size of the viewport is changed instead, so it can still be called from Layout
 func (c *Camera) Resize(w, h int) *Camera {
-	c.Width = w
-	c.Height = h
-	newW := int(float64(w) * 1.0 / c.Scale)
-	newH := int(float64(h) * 1.0 / c.Scale)
-	if newW <= 16384 && newH <= 16384 {
-		c.Surface.Deallocate()
-		c.Surface = ebiten.NewImage(newW, newH)
+	if c.pixel != nil {
+		c.pixel.screenWidth = maxInt(w, 1)
+		c.pixel.screenHeight = maxInt(h, 1)
+		return c
 	}
-	return c
+	c.Width = maxInt(w, 1)
+	c.Height = maxInt(h, 1)
+	c.resizeSurface(c.Width, c.Height)
+	return c.ClampToBounds()
+}
+
+// resizeSurface makes Surface w*h, growing the image behind it if needed
+func (c *Camera) resizeSurface(w, h int) {
+	if c.backing == nil || w > c.backing.Bounds().Dx() || h > c.backing.Bounds().Dy() {
+		bw, bh := w, h
+		if c.backing != nil {
+			bw = maxInt(bw, c.backing.Bounds().Dx())
+			bh = maxInt(bh, c.backing.Bounds().Dy())
+			c.backing.Deallocate()
+		}
+		c.backing = ebiten.NewImage(bw, bh)
+	}
+	c.Surface = c.backing.SubImage(image.Rect(0, 0, w, h)).(*ebiten.Image)
+}
+
+// SetViewport makes the camera draw to the screen rectangle x,y,w,h instead of the top left of the screen, e.g. for
+// split screen. Screen coords used by the camera are still relative to the whole screen
+func (c *Camera) SetViewport(x, y, w, h int) *Camera {
+	c.ViewportX = x
+	c.ViewportY = y
+	return c.Resize(w, h)
+}
+
+// GetViewport returns the screen rectangle the camera draws to
+func (c *Camera) GetViewport() image.Rectangle {
+	if c.pixel != nil {
+		return image.Rect(c.ViewportX, c.ViewportY, c.ViewportX+c.pixel.screenWidth, c.ViewportY+c.pixel.screenHeight)
+	}
+	return image.Rect(c.ViewportX, c.ViewportY, c.ViewportX+c.Width, c.ViewportY+c.Height)
+}
+
+// Update moves the camera towards the target it's following and updates the shake, pass TickDuration() when calling
+// it from ebiten's Update
+func (c *Camera) Update(dt time.Duration) {
+	if c.zooming != nil {
+		c.zooming.update(c, dt.Seconds())
+	}
+	if c.Following != nil {
+		c.Following.update(c, dt.Seconds())
+	}
+	c.Shake.update(dt.Seconds())
 }
 
 // GetTranslation alters the provided *ebiten.DrawImageOptions' translation based on the given x,y offset and the
-// camera's position
+// camera's position, then applies zoom. In pixel perfect mode the position is snapped to whole pixels of the Surface
 func (c *Camera) GetTranslation(ops *ebiten.DrawImageOptions, x, y float64) *ebiten.DrawImageOptions {
-	surfaceSize := c.Surface.Bounds().Size()
-	ops.GeoM.Translate(float64(surfaceSize.X)/2, float64(surfaceSize.Y)/2)
-	ops.GeoM.Translate(-c.Position.X+x, -c.Position.Y+y)
+	px, py := c.Position.X, c.Position.Y
+	if c.pixel != nil {
+		px, py, _, _ = c.getPixelSnap()
+	}
+	ops.GeoM.Translate(-px+x, -py+y)
+	ops.GeoM.Scale(c.Scale, c.Scale)
+	ops.GeoM.Translate(float64(c.Width)/2, float64(c.Height)/2)
 	return ops
 }
 
@@ -144,18 +204,40 @@ func (c *Camera) GetSkew(ops *ebiten.DrawImageOptions, skewX, skewY float64) *eb
 	return ops
 }
 
-// Blit draws the camera's surface to the passed *ebiten.Image and applies zoom
+// Blit draws the parallax layers and then the camera's surface to its viewport of the passed *ebiten.Image, and applies
+// rotation and shake. In pixel perfect mode it's scaled up and letterboxed
 func (c *Camera) Blit(surface *ebiten.Image) {
 	op := &ebiten.DrawImageOptions{}
-	surfaceSize := c.Surface.Bounds().Size()
-	cx := float64(surfaceSize.X) / 2.0
-	cy := float64(surfaceSize.Y) / 2.0
+	area := c.GetViewport()
+	scale := 1.0
+	if c.pixel != nil {
+		area = c.getLetterbox()
+		scale = float64(c.GetPixelScale())
+		op.GeoM.Scale(scale, scale)
+		if c.pixel.subpixel {
+			// the Surface was drawn up to a pixel too far, move it back by whole screen pixels
+			_, _, fx, fy := c.getPixelSnap()
+			op.GeoM.Translate(-math.Round(fx*scale), -math.Round(fy*scale))
+		}
+	}
+
+	// keep the rotated surface inside of the viewport
+	area = area.Add(surface.Bounds().Min)
+	surface = surface.SubImage(area).(*ebiten.Image)
+
+	cx := float64(c.Width) * scale / 2.0
+	cy := float64(c.Height) * scale / 2.0
 
-	op.GeoM.Translate(-cx, -cy)
-	op.GeoM.Scale(c.Scale, c.Scale)
-	op.GeoM.Rotate(c.ScreenRotation)
-	op.GeoM.Translate(cx*c.Scale, cy*c.Scale)
+	shakeX, shakeY, shakeRotation := c.GetShakeOffset()
 
+	screen := ebiten.GeoM{}
+	screen.Translate(-cx, -cy)
+	screen.Rotate(c.ScreenRotation + shakeRotation)
+	screen.Translate(cx+shakeX+float64(area.Min.X), cy+shakeY+float64(area.Min.Y))
+
+	c.drawParallax(surface, screen, scale, c.ScreenRotation+shakeRotation)
+
+	op.GeoM.Concat(screen)
 	surface.DrawImage(c.Surface, op)
 }
 
@@ -166,9 +248,15 @@ func (c *Camera) GetScreenCoords(x, y float64) (float64, float64) {
 	si := math.Sin(c.ScreenRotation)
 
 	x, y = x-c.Position.X, y-c.Position.Y
+	if c.pixel != nil && !c.pixel.subpixel {
+		_, _, fx, fy := c.getPixelSnap()
+		x, y = x+fx/c.Scale, y+fy/c.Scale
+	}
 	x, y = co*x-si*y, si*x+co*y
+	x, y = x*c.Scale+float64(w)/2, y*c.Scale+float64(h)/2
 
-	return x*c.Scale + float64(w)/2, y*c.Scale + float64(h)/2
+	ox, oy, scale := c.getScreenOrigin()
+	return x*scale + ox, y*scale + oy
 }
 
 // GetWorldCoords converts screen coords into world coords
@@ -177,8 +265,14 @@ func (c *Camera) GetWorldCoords(x, y float64) (float64, float64) {
 	co := math.Cos(-c.ScreenRotation)
 	si := math.Sin(-c.ScreenRotation)
 
+	ox, oy, scale := c.getScreenOrigin()
+	x, y = (x-ox)/scale, (y-oy)/scale
 	x, y = (x-float64(w)/2)/c.Scale, (y-float64(h)/2)/c.Scale
 	x, y = co*x-si*y, si*x+co*y
+	if c.pixel != nil && !c.pixel.subpixel {
+		_, _, fx, fy := c.getPixelSnap()
+		x, y = x-fx/c.Scale, y-fy/c.Scale
+	}
 
 	return x + c.Position.X, y + c.Position.Y
 }
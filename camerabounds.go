@@ -0,0 +1,53 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import "math"
+
+// SetBounds stops the camera from showing anything outside of the world space rectangle x1,y1 to x2,y2, e.g. the
+// size of a World in pixels. If the rectangle is smaller than the view, it's centered instead
+func (c *Camera) SetBounds(x1, y1, x2, y2 float64) *Camera {
+	c.bounded = true
+	c.bounds = [4]float64{math.Min(x1, x2), math.Min(y1, y2), math.Max(x1, x2), math.Max(y1, y2)}
+	return c.ClampToBounds()
+}
+
+// ClearBounds lets the camera move anywhere
+func (c *Camera) ClearBounds() *Camera {
+	c.bounded = false
+	return c
+}
+
+// GetBounds returns the rectangle set by SetBounds, ok is false if there isn't one
+func (c *Camera) GetBounds() (x1, y1, x2, y2 float64, ok bool) {
+	return c.bounds[0], c.bounds[1], c.bounds[2], c.bounds[3], c.bounded
+}
+
+// getViewExtents returns half of the size of the world space AABB which is visible, taking Scale and ScreenRotation
+// into account
+func (c *Camera) getViewExtents() (float64, float64) {
+	hw := float64(c.Width) / 2 / c.Scale
+	hh := float64(c.Height) / 2 / c.Scale
+	co := math.Abs(math.Cos(c.ScreenRotation))
+	si := math.Abs(math.Sin(c.ScreenRotation))
+	return hw*co + hh*si, hw*si + hh*co
+}
+
+// ClampToBounds moves the camera so that it doesn't show anything outside of its bounds. It's called automatically
+// when the camera is moved, rotated, zoomed or resized, but not when Position is changed directly
+func (c *Camera) ClampToBounds() *Camera {
+	if !c.bounded || c.Position == nil {
+		return c
+	}
+	ex, ey := c.getViewExtents()
+	c.Position.X = clampAxis(c.Position.X, c.bounds[0], c.bounds[2], ex)
+	c.Position.Y = clampAxis(c.Position.Y, c.bounds[1], c.bounds[3], ey)
+	return c
+}
+
+// clampAxis keeps pos-extent and pos+extent between min and max, or centers pos if they don't fit
+func clampAxis(pos, min, max, extent float64) float64 {
+	if max-min <= extent*2 {
+		return (min + max) / 2
+	}
+	return math.Max(min+extent, math.Min(max-extent, pos))
+}
@@ -0,0 +1,26 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+// GetVisibleBounds returns the world space AABB which the camera can see, taking zoom and ScreenRotation into account
+func (c *Camera) GetVisibleBounds() (x1, y1, x2, y2 float64) {
+	ex, ey := c.getViewExtents()
+	return c.Position.X - ex, c.Position.Y - ey, c.Position.X + ex, c.Position.Y + ey
+}
+
+// IsVisible returns true if any part of the world space rectangle x,y,w,h can be seen by the camera. Use it to skip
+// drawing things which are off screen
+func (c *Camera) IsVisible(x, y, w, h float64) bool {
+	x1, y1, x2, y2 := c.GetVisibleBounds()
+	return x+w >= x1 && x <= x2 && y+h >= y1 && y <= y2
+}
+
+// QueryVisible returns the shapes in the hash which can be seen by the camera. Use SetParent on the shapes to find
+// what needs to be drawn
+func (c *Camera) QueryVisible(hash *SpatialHash) []Shape {
+	return hash.Query(c.GetVisibleBounds())
+}
+
+// isNear returns true if something within radius of pos can be seen by the camera
+func (c *Camera) isNear(pos *Vector2, radius float64) bool {
+	return c.IsVisible(pos.X-radius, pos.Y-radius, radius*2, radius*2)
+}
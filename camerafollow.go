@@ -0,0 +1,198 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import "math"
+
+// FollowSmoothing specifies how a Camera moves towards the target it's following
+type FollowSmoothing int8
+
+// Follow smoothing modes
+const (
+	FollowInstant FollowSmoothing = iota // snaps to the target
+	FollowLerp                           // moves part of the way each update, quickly at first then slowing down
+	FollowSpring                         // accelerates towards the target and can overshoot it if Damping is low
+)
+
+// CameraFollow stores how a Camera follows its target, it's returned by Camera.Follow so it can be configured
+type CameraFollow struct {
+	Target *Vector2
+	Shape  Shape // optional, the center of its bounds is followed instead of Target
+
+	Smoothing          FollowSmoothing
+	LerpSpeed          float64 // FollowLerp, higher is faster
+	Stiffness, Damping float64 // FollowSpring
+
+	// the camera doesn't move while the target is inside this rectangle, in world units around the camera's position
+	DeadzoneWidth, DeadzoneHeight float64
+
+	LookAhead      float64 // how far ahead of the target to look in the direction it's moving, in world units
+	LookAheadSpeed float64 // how quickly the look-ahead changes, higher is faster
+
+	LockX, LockY bool // stop the camera from moving along an axis
+
+	velocity   *Vector2 // used by FollowSpring
+	lookAhead  *Vector2
+	lastTarget *Vector2
+}
+
+// Follow makes Update move the camera towards target, which is usually the position of the player. The settings of
+// the previous target are kept
+func (c *Camera) Follow(target *Vector2) *CameraFollow {
+	if c.Following == nil {
+		c.Following = &CameraFollow{
+			Smoothing:      FollowLerp,
+			LerpSpeed:      5,
+			Stiffness:      100,
+			Damping:        20,
+			LookAheadSpeed: 3,
+			velocity:       NewVector2(0, 0),
+			lookAhead:      NewVector2(0, 0),
+		}
+	}
+	c.Following.Target = target
+	c.Following.Shape = nil
+	c.Following.lastTarget = nil
+	return c.Following
+}
+
+// FollowShape makes Update move the camera towards the center of the shape
+func (c *Camera) FollowShape(shape Shape) *CameraFollow {
+	f := c.Follow(nil)
+	f.Shape = shape
+	return f
+}
+
+// StopFollowing stops Update from moving the camera
+func (c *Camera) StopFollowing() *Camera {
+	c.Following = nil
+	return c
+}
+
+// SetLerp uses FollowLerp, speed is how quickly the camera catches up, 5 by default
+func (f *CameraFollow) SetLerp(speed float64) *CameraFollow {
+	f.Smoothing = FollowLerp
+	f.LerpSpeed = speed
+	return f
+}
+
+// SetSpring uses FollowSpring, damping = 2*sqrt(stiffness) is as fast as possible without overshooting
+func (f *CameraFollow) SetSpring(stiffness, damping float64) *CameraFollow {
+	f.Smoothing = FollowSpring
+	f.Stiffness = stiffness
+	f.Damping = damping
+	return f
+}
+
+// SetInstant makes the camera snap to the target
+func (f *CameraFollow) SetInstant() *CameraFollow {
+	f.Smoothing = FollowInstant
+	return f
+}
+
+// SetDeadzone sets the size of the rectangle the target can move in without moving the camera
+func (f *CameraFollow) SetDeadzone(w, h float64) *CameraFollow {
+	f.DeadzoneWidth = w
+	f.DeadzoneHeight = h
+	return f
+}
+
+// SetLookAhead sets how far ahead of the target the camera looks, and how quickly it changes
+func (f *CameraFollow) SetLookAhead(distance, speed float64) *CameraFollow {
+	f.LookAhead = distance
+	f.LookAheadSpeed = speed
+	return f
+}
+
+// SetLock stops the camera from moving horizontally and/or vertically
+func (f *CameraFollow) SetLock(x, y bool) *CameraFollow {
+	f.LockX = x
+	f.LockY = y
+	return f
+}
+
+// getTarget returns the position which is being followed, or nil if there isn't one
+func (f *CameraFollow) getTarget() *Vector2 {
+	if f.Shape != nil {
+		x1, y1, x2, y2 := f.Shape.GetBounds()
+		return NewVector2((x1+x2)/2, (y1+y2)/2)
+	}
+	if f.Target != nil {
+		return f.Target.Clone()
+	}
+	return nil
+}
+
+// update moves the camera by dt seconds
+func (f *CameraFollow) update(c *Camera, dt float64) {
+	target := f.getTarget()
+	if target == nil || dt <= 0 {
+		return
+	}
+
+	// look ahead in the direction the target is moving
+	if f.lastTarget != nil && f.LookAhead != 0 {
+		motion := target.Sub(f.lastTarget)
+		ahead := NewVector2(0, 0)
+		if motion.Length() > 0.0001 {
+			ahead = motion.Normalize().Mult(f.LookAhead)
+		}
+		t := 1 - math.Exp(-f.LookAheadSpeed*dt)
+		f.lookAhead.X += (ahead.X - f.lookAhead.X) * t
+		f.lookAhead.Y += (ahead.Y - f.lookAhead.Y) * t
+	}
+	f.lastTarget = target
+	target = target.Add(f.lookAhead)
+
+	// only move far enough to keep the target inside the deadzone
+	goal := c.Position.Clone()
+	goal.X = deadzoneAxis(goal.X, target.X, f.DeadzoneWidth/2)
+	goal.Y = deadzoneAxis(goal.Y, target.Y, f.DeadzoneHeight/2)
+
+	next := goal
+	switch f.Smoothing {
+	case FollowLerp:
+		t := 1 - math.Exp(-f.LerpSpeed*dt)
+		next = NewVector2(
+			c.Position.X+(goal.X-c.Position.X)*t,
+			c.Position.Y+(goal.Y-c.Position.Y)*t)
+	case FollowSpring:
+		// stepped in small pieces like the punch spring, so a long frame doesn't make it explode
+		next = c.Position.Clone()
+		for left := dt; left > 0; left -= springStep {
+			step := math.Min(left, springStep)
+			f.velocity.X += (f.Stiffness*(goal.X-next.X) - f.Damping*f.velocity.X) * step
+			f.velocity.Y += (f.Stiffness*(goal.Y-next.Y) - f.Damping*f.velocity.Y) * step
+			next.X += f.velocity.X * step
+			next.Y += f.velocity.Y * step
+		}
+	}
+
+	if f.LockX {
+		next.X = c.Position.X
+		f.velocity.X = 0
+	}
+	if f.LockY {
+		next.Y = c.Position.Y
+		f.velocity.Y = 0
+	}
+	c.SetPosition(next.X, next.Y)
+
+	// stop the spring pushing against the bounds, or it keeps building speed there
+	if c.Position.X != next.X {
+		f.velocity.X = 0
+	}
+	if c.Position.Y != next.Y {
+		f.velocity.Y = 0
+	}
+}
+
+// deadzoneAxis returns the position along one axis which keeps target within half of it
+func deadzoneAxis(pos, target, half float64) float64 {
+	if target > pos+half {
+		return target - half
+	}
+	if target < pos-half {
+		return target + half
+	}
+	return pos
+}
@@ -0,0 +1,34 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCameraFollowSpringLongFrames(t *testing.T) {
+	c := NewCamera(100, 100, 0, 0, 0, 1)
+	c.Follow(NewVector2(1000, 0)).SetSpring(500, 10)
+	for i := 0; i < 10; i++ {
+		c.Update(time.Second / 2)
+	}
+	if math.IsNaN(c.Position.X) || math.Abs(c.Position.X-1000) > 1 {
+		t.Fatalf("spring went unstable or didn't settle: %v", c.Position.X)
+	}
+}
+
+func TestCameraFollowSpringBounds(t *testing.T) {
+	c := NewCamera(100, 100, 0, 0, 0, 1)
+	c.SetBounds(-50, -50, 150, 150)
+	f := c.Follow(NewVector2(1000, 0)).SetSpring(100, 1)
+	for i := 0; i < 60; i++ {
+		c.Update(time.Second / 60)
+	}
+	if c.Position.X != 100 {
+		t.Fatalf("camera should be clamped at 100, got %v", c.Position.X)
+	}
+	if f.velocity.X != 0 {
+		t.Fatalf("velocity should be zeroed at the bounds, got %v", f.velocity.X)
+	}
+}
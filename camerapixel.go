@@ -0,0 +1,86 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image"
+	"math"
+)
+
+// cameraPixelPerfect is set by SetPixelPerfect
+type cameraPixelPerfect struct {
+	subpixel                  bool
+	screenWidth, screenHeight int // size of the viewport the Surface is scaled up to
+}
+
+// SetPixelPerfect makes the camera render pixel art without shimmering. The Surface becomes width*height, e.g. 320*180,
+// and the world is drawn to it at whole pixels. Blit scales it up by the biggest whole number which fits the viewport
+// and letterboxes the rest, see GetPixelScale. Use a whole number zoom to keep every pixel the same size.
+//
+// Snapping the position makes slow scrolling look jerky, subpixel smooths it out by moving the scaled up Surface by
+// the part of a pixel that was snapped off. The Surface is 1 pixel bigger than width*height to make room for it
+func (c *Camera) SetPixelPerfect(width, height int, subpixel bool) *Camera {
+	if c.pixel == nil {
+		c.pixel = &cameraPixelPerfect{screenWidth: c.Width, screenHeight: c.Height}
+	}
+	c.pixel.subpixel = subpixel
+	c.Width = maxInt(width, 1)
+	c.Height = maxInt(height, 1)
+	if subpixel {
+		c.resizeSurface(c.Width+1, c.Height+1)
+	} else {
+		c.resizeSurface(c.Width, c.Height)
+	}
+	return c.ClampToBounds()
+}
+
+// DisablePixelPerfect goes back to rendering at the size of the viewport
+func (c *Camera) DisablePixelPerfect() *Camera {
+	if c.pixel == nil {
+		return c
+	}
+	w, h := c.pixel.screenWidth, c.pixel.screenHeight
+	c.pixel = nil
+	return c.Resize(w, h)
+}
+
+// IsPixelPerfect returns true if SetPixelPerfect has been used
+func (c *Camera) IsPixelPerfect() bool {
+	return c.pixel != nil
+}
+
+// GetPixelScale returns how many screen pixels wide each pixel of the Surface is when it's blitted, it's always 1
+// unless the camera is pixel perfect
+func (c *Camera) GetPixelScale() int {
+	if c.pixel == nil {
+		return 1
+	}
+	return maxInt(1, minInt(c.pixel.screenWidth/c.Width, c.pixel.screenHeight/c.Height))
+}
+
+// getLetterbox returns the screen rectangle the scaled up Surface is drawn to, centered in the viewport
+func (c *Camera) getLetterbox() image.Rectangle {
+	vp := c.GetViewport()
+	s := c.GetPixelScale()
+	w, h := c.Width*s, c.Height*s
+	x, y := vp.Min.X+(vp.Dx()-w)/2, vp.Min.Y+(vp.Dy()-h)/2
+	return image.Rect(x, y, x+w, y+h)
+}
+
+// getScreenOrigin returns where the top left of the Surface is on the screen, before rotation, and how much it's
+// scaled up by
+func (c *Camera) getScreenOrigin() (x, y, scale float64) {
+	if c.pixel == nil {
+		return float64(c.ViewportX), float64(c.ViewportY), 1
+	}
+	box := c.getLetterbox()
+	return float64(box.Min.X), float64(box.Min.Y), float64(c.GetPixelScale())
+}
+
+// getPixelSnap returns the position GetTranslation uses so that the world is drawn at whole pixels, and how many
+// pixels of the Surface the world was moved by (0 to 1 for each axis)
+func (c *Camera) getPixelSnap() (x, y, fx, fy float64) {
+	hw, hh := float64(c.Width)/2, float64(c.Height)/2
+	tx, ty := hw-c.Position.X*c.Scale, hh-c.Position.Y*c.Scale
+	sx, sy := math.Ceil(tx), math.Ceil(ty)
+	return (hw - sx) / c.Scale, (hh - sy) / c.Scale, sx - tx, sy - ty
+}
@@ -0,0 +1,101 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import "math"
+
+// CameraShake shakes the camera by an amount which depends on Trauma. The shake is only applied by Blit so Position
+// and GetWorldCoords aren't affected by it
+type CameraShake struct {
+	Trauma      float64 // 0-1, the shake is Trauma squared so small amounts are subtle
+	Decay       float64 // how much trauma is lost per second, 0 is treated as 1
+	MaxOffset   float64 // screen pixels, 0 is treated as 24
+	MaxRotation float64 // radians, 0 is treated as 0.05
+	Frequency   float64 // how quickly the shake changes, 0 is treated as 15
+
+	PunchStiffness float64 // how quickly punches spring back, 0 is treated as 300
+	PunchDamping   float64 // 0 is treated as 20
+
+	punchX, punchY       float64 // world units
+	punchVelX, punchVelY float64
+	time                 float64
+	noise                *Noise
+}
+
+// AddTrauma adds to the camera's trauma, up to 1. Something like 0.2 for a hit and 0.6 for an explosion
+func (c *Camera) AddTrauma(amount float64) *Camera {
+	c.Shake.Trauma = math.Max(0, math.Min(1, c.Shake.Trauma+amount))
+	return c
+}
+
+// Punch pushes the view by x,y world units, which then springs back, e.g. for recoil
+func (c *Camera) Punch(x, y float64) *Camera {
+	c.Shake.punchX += x
+	c.Shake.punchY += y
+	return c
+}
+
+// Kick pushes the view in the direction of angle, see Punch
+func (c *Camera) Kick(angle, strength float64) *Camera {
+	return c.Punch(math.Cos(angle)*strength, math.Sin(angle)*strength)
+}
+
+// springStep is the longest step in seconds that springs are updated by
+const springStep = 1.0 / 120
+
+// update decays the trauma and springs the punch back by dt seconds
+func (s *CameraShake) update(dt float64) {
+	decay := s.Decay
+	if decay == 0 {
+		decay = 1
+	}
+	s.Trauma = math.Max(0, s.Trauma-decay*dt)
+	s.time += dt
+
+	stiffness, damping := s.PunchStiffness, s.PunchDamping
+	if stiffness == 0 {
+		stiffness = 300
+	}
+	if damping == 0 {
+		damping = 20
+	}
+	// the spring is stepped in small pieces so a long frame doesn't make it explode
+	for left := dt; left > 0; left -= springStep {
+		step := math.Min(left, springStep)
+		s.punchVelX += (-stiffness*s.punchX - damping*s.punchVelX) * step
+		s.punchVelY += (-stiffness*s.punchY - damping*s.punchVelY) * step
+		s.punchX += s.punchVelX * step
+		s.punchY += s.punchVelY * step
+	}
+}
+
+// GetShakeOffset returns how far the view is moved in screen pixels and rotated by the shake and punches. Use it to
+// shake things which are drawn straight to the screen
+func (c *Camera) GetShakeOffset() (x, y, rotation float64) {
+	s := &c.Shake
+	if s.noise == nil {
+		s.noise = NewNoise(0)
+	}
+	maxOffset, maxRotation, frequency := s.MaxOffset, s.MaxRotation, s.Frequency
+	if maxOffset == 0 {
+		maxOffset = 24
+	}
+	if maxRotation == 0 {
+		maxRotation = 0.05
+	}
+	if frequency == 0 {
+		frequency = 15
+	}
+
+	shake := s.Trauma * s.Trauma
+	t := s.time * frequency
+	x = shake * maxOffset * s.noise.Simplex2D(t, 0)
+	y = shake * maxOffset * s.noise.Simplex2D(t, 100)
+	rotation = shake * maxRotation * s.noise.Simplex2D(t, 200)
+
+	// punches are in world units, so they're scaled and rotated like the world is
+	co, si := math.Cos(c.ScreenRotation), math.Sin(c.ScreenRotation)
+	scale := c.Scale * float64(c.GetPixelScale())
+	x += (co*s.punchX - si*s.punchY) * scale
+	y += (si*s.punchX + co*s.punchY) * scale
+	return x, y, rotation
+}
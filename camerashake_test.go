@@ -0,0 +1,22 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCameraShakePunchLongFrames(t *testing.T) {
+	s := &CameraShake{}
+	s.punchX = 10
+	// a stiff spring stepped by a whole second at once would blow up without substeps
+	for i := 0; i < 5; i++ {
+		s.update(1)
+	}
+	if math.IsNaN(s.punchX) || math.Abs(s.punchX) > 10 {
+		t.Fatalf("punch went unstable: %v", s.punchX)
+	}
+	if math.Abs(s.punchX) > 0.01 {
+		t.Fatalf("punch didn't settle: %v", s.punchX)
+	}
+}
@@ -0,0 +1,66 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import "time"
+
+// cameraZoom animates the zoom from one value to another
+type cameraZoom struct {
+	from, to         float64
+	elapsed, length  float64 // seconds
+	anchored         bool
+	anchorX, anchorY float64 // screen coords which stay in place
+}
+
+// ZoomAt multiplies the zoom by mul while keeping the world position under the screen coords x,y in the same place,
+// e.g. zooming towards the cursor with the mouse wheel
+func (c *Camera) ZoomAt(mul, x, y float64) *Camera {
+	return c.setZoomAt(c.Scale*mul, x, y)
+}
+
+// setZoomAt sets the zoom while keeping the world position under the screen coords x,y in the same place
+func (c *Camera) setZoomAt(zoom, x, y float64) *Camera {
+	wx, wy := c.GetWorldCoords(x, y)
+	c.SetZoom(zoom)
+	nx, ny := c.GetWorldCoords(x, y)
+	return c.MovePosition(wx-nx, wy-ny)
+}
+
+// ZoomTo smoothly changes the zoom to target over duration while Update is called
+func (c *Camera) ZoomTo(target float64, duration time.Duration) *Camera {
+	c.zooming = &cameraZoom{from: c.Scale, to: target, length: duration.Seconds()}
+	return c
+}
+
+// ZoomToAt smoothly changes the zoom like ZoomTo, while keeping the world position under the screen coords x,y in the
+// same place like ZoomAt
+func (c *Camera) ZoomToAt(target float64, duration time.Duration, x, y float64) *Camera {
+	c.ZoomTo(target, duration)
+	c.zooming.anchored = true
+	c.zooming.anchorX, c.zooming.anchorY = x, y
+	return c
+}
+
+// IsZooming returns true while ZoomTo is changing the zoom
+func (c *Camera) IsZooming() bool {
+	return c.zooming != nil
+}
+
+// update moves the zoom along by dt seconds, and stops once it's finished
+func (z *cameraZoom) update(c *Camera, dt float64) {
+	z.elapsed += dt
+	t := 1.0
+	if z.length > 0 && z.elapsed < z.length {
+		t = z.elapsed / z.length
+		t = t * t * (3 - 2*t) // ease in and out
+	}
+
+	zoom := z.from + (z.to-z.from)*t
+	if z.anchored {
+		c.setZoomAt(zoom, z.anchorX, z.anchorY)
+	} else {
+		c.SetZoom(zoom)
+	}
+	if t >= 1 {
+		c.zooming = nil
+	}
+}
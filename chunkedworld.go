@@ -0,0 +1,330 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+var (
+	// ErrChunkNotLoaded is returned when a tile is accessed in a chunk which isn't loaded
+	ErrChunkNotLoaded = errors.New("Chunk not loaded")
+	// ErrChunkCorrupt is returned when a saved chunk's layers don't match its size
+	ErrChunkCorrupt = errors.New("Chunk file is corrupt")
+)
+
+// ChunkGenerator fills a newly created chunk. cx and cy are the coords of the chunk and seed is derived from the
+// ChunkedWorld's Seed and the coords, so the same chunk is always generated the same way. The chunk's own generators
+// are already seeded with it
+type ChunkGenerator func(chunk *World, cx, cy int, seed int64) error
+
+// ChunkStore persists chunks which have been modified so they aren't regenerated when they're loaded again
+type ChunkStore interface {
+	LoadChunk(cx, cy int) (*World, error) // returns nil, nil if the chunk hasn't been saved
+	SaveChunk(cx, cy int, chunk *World) error
+}
+
+// ChunkedWorld is a World which is split into chunks that are generated around the Camera on demand and unloaded when
+// they're far away, so it can scroll forever. Tiles are accessed with global tile coords, which can be negative
+type ChunkedWorld struct {
+	ChunkWidth, ChunkHeight int // how many tiles are in each chunk
+	TileWidth, TileHeight   int // how big each tile is in pixels, used by Update
+	LoadRadius              int // how many chunks around the center chunk are loaded
+	UnloadRadius            int // chunks further than this from the center chunk are unloaded
+
+	Seed      int64
+	Generator ChunkGenerator
+	Store     ChunkStore // optional
+
+	Chunks   map[CellCoord]*World
+	modified map[CellCoord]struct{}
+
+	OnChunkLoaded   func(cx, cy int, chunk *World) // optional, called after a chunk is generated or loaded
+	OnChunkUnloaded func(cx, cy int, chunk *World) // optional, called before a chunk is unloaded
+}
+
+// NewChunkedWorld returns a new *ChunkedWorld
+func NewChunkedWorld(chunkWidth, chunkHeight, tileWidth, tileHeight int, seed int64, generator ChunkGenerator) *ChunkedWorld {
+	return &ChunkedWorld{
+		ChunkWidth:   chunkWidth,
+		ChunkHeight:  chunkHeight,
+		TileWidth:    tileWidth,
+		TileHeight:   tileHeight,
+		LoadRadius:   1,
+		UnloadRadius: 2,
+
+		Seed:      seed,
+		Generator: generator,
+
+		Chunks:   make(map[CellCoord]*World),
+		modified: make(map[CellCoord]struct{}),
+	}
+}
+
+// ChunkSeed returns a seed which is unique to the chunk at cx,cy
+func ChunkSeed(seed int64, cx, cy int) int64 {
+	h := uint64(seed)
+	h ^= uint64(int64(cx)) * 0x9e3779b97f4a7c15
+	h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+	h ^= uint64(int64(cy)) * 0x94d049bb133111eb
+	h = (h ^ h>>27) * 0x94d049bb133111eb
+	h ^= h >> 31
+	return int64(h)
+}
+
+// GetChunkCoords converts global tile coords into the coords of the containing chunk and the tile coords inside of it
+func (cw *ChunkedWorld) GetChunkCoords(x, y int) (CellCoord, int, int) {
+	c := CellCoord{
+		int(math.Floor(float64(x) / float64(cw.ChunkWidth))),
+		int(math.Floor(float64(y) / float64(cw.ChunkHeight))),
+	}
+	return c, x - c.X*cw.ChunkWidth, y - c.Y*cw.ChunkHeight
+}
+
+// Update loads the chunks around the camera and unloads the ones which are too far away
+func (cw *ChunkedWorld) Update(camera *Camera) error {
+	return cw.UpdateAround(
+		int(math.Floor(camera.Position.X/float64(cw.TileWidth))),
+		int(math.Floor(camera.Position.Y/float64(cw.TileHeight))))
+}
+
+// UpdateAround loads the chunks around the global tile coords x,y and unloads the ones which are too far away
+func (cw *ChunkedWorld) UpdateAround(x, y int) error {
+	center, _, _ := cw.GetChunkCoords(x, y)
+
+	for c, chunk := range cw.Chunks {
+		if absInt(c.X-center.X) > cw.UnloadRadius || absInt(c.Y-center.Y) > cw.UnloadRadius {
+			if err := cw.unloadChunk(c, chunk); err != nil {
+				return err
+			}
+		}
+	}
+
+	for cy := center.Y - cw.LoadRadius; cy <= center.Y+cw.LoadRadius; cy++ {
+		for cx := center.X - cw.LoadRadius; cx <= center.X+cw.LoadRadius; cx++ {
+			if _, err := cw.LoadChunk(cx, cy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// LoadChunk returns the chunk at cx,cy, loading it from the Store or generating it if it isn't loaded yet
+func (cw *ChunkedWorld) LoadChunk(cx, cy int) (*World, error) {
+	c := CellCoord{cx, cy}
+	if chunk, ok := cw.Chunks[c]; ok {
+		return chunk, nil
+	}
+
+	var chunk *World
+	if cw.Store != nil {
+		var err error
+		if chunk, err = cw.Store.LoadChunk(cx, cy); err != nil {
+			return nil, err
+		}
+	}
+	if chunk == nil {
+		chunk = NewWorld(cw.ChunkWidth, cw.ChunkHeight)
+		chunk.Border = 0
+		seed := ChunkSeed(cw.Seed, cx, cy)
+		chunk.SetSeed(seed)
+		if cw.Generator != nil {
+			if err := cw.Generator(chunk, cx, cy, seed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	cw.Chunks[c] = chunk
+	if cw.OnChunkLoaded != nil {
+		cw.OnChunkLoaded(cx, cy, chunk)
+	}
+	return chunk, nil
+}
+
+// unloadChunk saves the chunk if it was modified and removes it
+func (cw *ChunkedWorld) unloadChunk(c CellCoord, chunk *World) error {
+	if cw.OnChunkUnloaded != nil {
+		cw.OnChunkUnloaded(c.X, c.Y, chunk)
+	}
+	if _, ok := cw.modified[c]; ok && cw.Store != nil {
+		if err := cw.Store.SaveChunk(c.X, c.Y, chunk); err != nil {
+			return err
+		}
+	}
+	delete(cw.modified, c)
+	delete(cw.Chunks, c)
+	return nil
+}
+
+// MarkModified marks the chunk at cx,cy to be saved when it's unloaded. SetTile and SetLayerTile already do this, so
+// it's only needed if the chunk's tiles were changed directly. Chunks which aren't loaded are ignored
+func (cw *ChunkedWorld) MarkModified(cx, cy int) {
+	c := CellCoord{cx, cy}
+	if _, ok := cw.Chunks[c]; ok {
+		cw.modified[c] = struct{}{}
+	}
+}
+
+// SaveAll saves every loaded chunk which was modified to the Store
+func (cw *ChunkedWorld) SaveAll() error {
+	if cw.Store == nil {
+		return nil
+	}
+	for c := range cw.modified {
+		if chunk, ok := cw.Chunks[c]; ok {
+			if err := cw.Store.SaveChunk(c.X, c.Y, chunk); err != nil {
+				return err
+			}
+		}
+		delete(cw.modified, c)
+	}
+	return nil
+}
+
+// GetTile returns a tile from the active layer of the chunk containing the global tile coords x,y
+func (cw *ChunkedWorld) GetTile(x, y int) (Tile, error) {
+	c, lx, ly := cw.GetChunkCoords(x, y)
+	chunk, ok := cw.Chunks[c]
+	if !ok {
+		return TileVoid, ErrChunkNotLoaded
+	}
+	return chunk.GetTile(lx, ly)
+}
+
+// SetTile sets a tile in the active layer of the chunk containing the global tile coords x,y
+func (cw *ChunkedWorld) SetTile(x, y int, t Tile) error {
+	c, lx, ly := cw.GetChunkCoords(x, y)
+	chunk, ok := cw.Chunks[c]
+	if !ok {
+		return ErrChunkNotLoaded
+	}
+	if err := chunk.SetTile(lx, ly, t); err != nil {
+		return err
+	}
+	cw.modified[c] = struct{}{}
+	return nil
+}
+
+// GetLayerTile returns a tile from the named layer of the chunk containing the global tile coords x,y
+func (cw *ChunkedWorld) GetLayerTile(layer string, x, y int) (Tile, error) {
+	c, lx, ly := cw.GetChunkCoords(x, y)
+	chunk, ok := cw.Chunks[c]
+	if !ok {
+		return TileVoid, ErrChunkNotLoaded
+	}
+	return chunk.GetLayerTile(layer, lx, ly)
+}
+
+// SetLayerTile sets a tile in the named layer of the chunk containing the global tile coords x,y
+func (cw *ChunkedWorld) SetLayerTile(layer string, x, y int, t Tile) error {
+	c, lx, ly := cw.GetChunkCoords(x, y)
+	chunk, ok := cw.Chunks[c]
+	if !ok {
+		return ErrChunkNotLoaded
+	}
+	if err := chunk.SetLayerTile(layer, lx, ly, t); err != nil {
+		return err
+	}
+	cw.modified[c] = struct{}{}
+	return nil
+}
+
+// FileChunkStore is a ChunkStore which saves each chunk as a file in Dir
+type FileChunkStore struct {
+	Dir string
+}
+
+// chunkFile is what's saved by FileChunkStore
+type chunkFile struct {
+	Width, Height int
+	ActiveLayer   string
+	LayerOrder    []string
+	Layers        map[string][][]Tile
+}
+
+// NewFileChunkStore returns a new *FileChunkStore, creating dir if it doesn't exist
+func NewFileChunkStore(dir string) (*FileChunkStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileChunkStore{Dir: dir}, nil
+}
+
+func (s *FileChunkStore) path(cx, cy int) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("%d_%d.chunk", cx, cy))
+}
+
+// LoadChunk loads a chunk from its file, returns nil, nil if it doesn't exist
+func (s *FileChunkStore) LoadChunk(cx, cy int) (*World, error) {
+	f, err := os.Open(s.path(cx, cy))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var data chunkFile
+	if err := gob.NewDecoder(f).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.Layers[data.ActiveLayer]; !ok {
+		return nil, ErrChunkCorrupt
+	}
+	for _, tiles := range data.Layers {
+		if len(tiles) != data.Height {
+			return nil, ErrChunkCorrupt
+		}
+		for _, row := range tiles {
+			if len(row) != data.Width {
+				return nil, ErrChunkCorrupt
+			}
+		}
+	}
+
+	chunk := NewWorld(data.Width, data.Height)
+	chunk.Border = 0
+	chunk.Layers = data.Layers
+	chunk.LayerOrder = data.LayerOrder
+	chunk.ActiveLayer = data.ActiveLayer
+	chunk.Tiles = data.Layers[data.ActiveLayer]
+	return chunk, nil
+}
+
+// SaveChunk saves a chunk to its file. It's written to a temporary file first, so a crash can't leave a half written
+// chunk behind
+func (s *FileChunkStore) SaveChunk(cx, cy int, chunk *World) error {
+	f, err := os.CreateTemp(s.Dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+
+	chunk.Layers[chunk.ActiveLayer] = chunk.Tiles // in case Tiles was replaced
+	err = gob.NewEncoder(f).Encode(chunkFile{
+		Width:       chunk.Width,
+		Height:      chunk.Height,
+		ActiveLayer: chunk.ActiveLayer,
+		LayerOrder:  chunk.LayerOrder,
+		Layers:      chunk.Layers,
+	})
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), s.path(cx, cy))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
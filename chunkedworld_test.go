@@ -0,0 +1,64 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileChunkStoreRoundTrip(t *testing.T) {
+	store, err := NewFileChunkStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	chunk := NewWorld(4, 3)
+	chunk.Tiles[2][3] = TileWall
+	if err := store.SaveChunk(1, -2, chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.LoadChunk(1, -2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Width != 4 || loaded.Height != 3 || loaded.Tiles[2][3] != TileWall {
+		t.Fatalf("loaded chunk doesn't match the saved one")
+	}
+
+	// only the chunk is left, not the temporary file
+	files, _ := os.ReadDir(store.Dir)
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+}
+
+func TestFileChunkStoreCorrupt(t *testing.T) {
+	store, err := NewFileChunkStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]chunkFile{
+		"missing active layer": {Width: 2, Height: 2, ActiveLayer: "a", Layers: map[string][][]Tile{}},
+		"wrong height":         {Width: 2, Height: 2, ActiveLayer: "a", Layers: map[string][][]Tile{"a": newTiles(2, 1)}},
+		"wrong width":          {Width: 2, Height: 2, ActiveLayer: "a", Layers: map[string][][]Tile{"a": newTiles(3, 2)}},
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Create(filepath.Join(store.Dir, "0_0.chunk"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = gob.NewEncoder(f).Encode(data)
+			f.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.LoadChunk(0, 0); !errors.Is(err, ErrChunkCorrupt) {
+				t.Fatalf("got %v, want ErrChunkCorrupt", err)
+			}
+		})
+	}
+}
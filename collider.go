@@ -4,6 +4,7 @@ package zen
 import (
 	"errors"
 	"math"
+	"sort"
 )
 
 var ErrShapeNotFound = errors.New("Couldn't remove shape from SpatialHash; not found")
@@ -60,6 +61,9 @@ type SpatialHash struct {
 	Hash map[CellCoord]*Cell
 	// Backref for shapes to find its containing cells
 	Backref map[Shape][]*Cell
+
+	order     map[Shape]uint64 // when each shape was added, queries are sorted by it so they're the same every frame
+	nextOrder uint64
 }
 
 // NewSpatialHash returns a new *SpatialHash
@@ -68,6 +72,7 @@ func NewSpatialHash(cellSize int) *SpatialHash {
 		CellSize: cellSize,
 		Hash:     make(map[CellCoord]*Cell),
 		Backref:  make(map[Shape][]*Cell),
+		order:    make(map[Shape]uint64),
 	}
 }
 
@@ -86,6 +91,14 @@ func (s *SpatialHash) GetXYWH(shape Shape) (float32, float32, float32, float32)
 func (s *SpatialHash) Add(shape Shape) {
 	x1, y1, x2, y2 := shape.GetBounds()
 
+	if s.order == nil {
+		s.order = make(map[Shape]uint64)
+	}
+	if _, ok := s.order[shape]; !ok {
+		s.order[shape] = s.nextOrder
+		s.nextOrder++
+	}
+
 	// make sure big shapes are constrained properly
 	xStep := x2 - x1
 	if xStep > float64(s.CellSize) {
@@ -118,6 +131,18 @@ done:
 
 // Remove removes a shape from the spatial hash
 func (s *SpatialHash) Remove(shape Shape) error {
+	delete(s.order, shape)
+	return s.removeFromCells(shape)
+}
+
+// update adds the shape to the cells it's in after it moved, without changing its order
+func (s *SpatialHash) update(shape Shape) {
+	s.removeFromCells(shape)
+	s.Add(shape)
+}
+
+// removeFromCells removes a shape from its cells
+func (s *SpatialHash) removeFromCells(shape Shape) error {
 	if cells, ok := s.Backref[shape]; ok {
 		for _, cell := range cells {
 			delete(cell.Shapes, shape)
@@ -128,21 +153,72 @@ func (s *SpatialHash) Remove(shape Shape) error {
 	return ErrShapeNotFound
 }
 
-// GetCollisionCandidates returns a list of all shapes in the same cells as shape
-func (s *SpatialHash) GetCollisionCandidates(shape Shape) []Shape {
+// Query returns every shape whose bounds overlap the rectangle x1,y1 to x2,y2, in the order they were added to the
+// hash so it's the same every frame
+func (s *SpatialHash) Query(x1, y1, x2, y2 float64) []Shape {
+	cs := float64(s.CellSize)
+	fx1, fy1 := math.Floor(x1/cs), math.Floor(y1/cs)
+	fx2, fy2 := math.Floor(x2/cs), math.Floor(y2/cs)
+
+	shapes := make([]Shape, 0)
 	shapesMap := make(map[Shape]struct{})
-	if cells, ok := s.Backref[shape]; ok {
-		for _, cell := range cells {
-			for _, sh := range cell.Shapes {
+	check := func(cell *Cell) {
+		for _, sh := range cell.Shapes {
+			if _, ok := shapesMap[sh]; ok {
+				continue
+			}
+			l, u, r, d := sh.GetBounds()
+			if r >= x1 && l <= x2 && d >= y1 && u <= y2 {
 				shapesMap[sh] = struct{}{}
+				shapes = append(shapes, sh)
+			}
+		}
+	}
+	// the cell count is worked out with floats so huge or infinite areas can't overflow
+	const maxCell = 1 << 52
+	small := (fx2-fx1+1)*(fy2-fy1+1) <= float64(len(s.Hash)) &&
+		math.Abs(fx1) < maxCell && math.Abs(fy1) < maxCell && math.Abs(fx2) < maxCell && math.Abs(fy2) < maxCell
+	if !small {
+		// the area is bigger than the hash, so it's faster to check every cell
+		for c, cell := range s.Hash {
+			if float64(c.X) >= fx1 && float64(c.X) <= fx2 && float64(c.Y) >= fy1 && float64(c.Y) <= fy2 {
+				check(cell)
+			}
+		}
+	} else {
+		for y := int(fy1); y <= int(fy2); y++ {
+			for x := int(fx1); x <= int(fx2); x++ {
+				if cell, ok := s.Hash[CellCoord{x, y}]; ok {
+					check(cell)
+				}
 			}
 		}
 	}
-	delete(shapesMap, shape)
+	s.sortShapes(shapes)
+	return shapes
+}
+
+// sortShapes sorts shapes by when they were added to the hash
+func (s *SpatialHash) sortShapes(shapes []Shape) {
+	sort.Slice(shapes, func(i, j int) bool {
+		return s.order[shapes[i]] < s.order[shapes[j]]
+	})
+}
+
+// GetCollisionCandidates returns a list of all shapes in the same cells as shape, in the order they were added to the
+// hash
+func (s *SpatialHash) GetCollisionCandidates(shape Shape) []Shape {
 	shapes := make([]Shape, 0)
-	for k := range shapesMap {
-		shapes = append(shapes, k)
+	shapesMap := map[Shape]struct{}{shape: {}}
+	for _, cell := range s.Backref[shape] {
+		for _, sh := range cell.Shapes {
+			if _, ok := shapesMap[sh]; !ok {
+				shapesMap[sh] = struct{}{}
+				shapes = append(shapes, sh)
+			}
+		}
 	}
+	s.sortShapes(shapes)
 	return shapes
 }
 
@@ -385,8 +461,7 @@ func (ci *CircleShape) MovePosition(x, y float64) {
 	ci.Pos.X += x
 	ci.Pos.Y += y
 	hash := ci.GetHash()
-	hash.Remove(ci)
-	hash.Add(ci)
+	hash.update(ci)
 }
 
 // SetPosition moves the CircleShape to x and y
@@ -394,8 +469,7 @@ func (ci *CircleShape) SetPosition(x, y float64) {
 	ci.Pos.X = x
 	ci.Pos.Y = y
 	hash := ci.GetHash()
-	hash.Remove(ci)
-	hash.Add(ci)
+	hash.update(ci)
 }
 
 // SetHash sets the hash
@@ -447,8 +521,7 @@ func (re *RectangleShape) MovePosition(x, y float64) {
 	re.Pos.X += x
 	re.Pos.Y += y
 	hash := re.GetHash()
-	hash.Remove(re)
-	hash.Add(re)
+	hash.update(re)
 }
 
 // SetPosition moves the RectangleShape to x and y
@@ -456,8 +529,7 @@ func (re *RectangleShape) SetPosition(x, y float64) {
 	re.Pos.X = x
 	re.Pos.Y = y
 	hash := re.GetHash()
-	hash.Remove(re)
-	hash.Add(re)
+	hash.update(re)
 }
 
 // SetHash sets the hash
@@ -0,0 +1,56 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpatialHashQueryOrder(t *testing.T) {
+	hash := NewSpatialHash(32)
+	var added []Shape
+	for i := 0; i < 50; i++ {
+		// lots of shapes in the same few cells, so map order would show up
+		added = append(added, hash.NewRectangleShape(float64(i%5)*10, float64(i%3)*10, 8, 8))
+	}
+	// moving a shape doesn't change its order
+	added[3].SetPosition(200, 200)
+	added[3].SetPosition(5, 5)
+
+	first := hash.Query(-100, -100, 100, 100)
+	if len(first) != len(added) {
+		t.Fatalf("got %d shapes, want %d", len(first), len(added))
+	}
+	for i := 0; i < 10; i++ {
+		again := hash.Query(-100, -100, 100, 100)
+		for j := range first {
+			if again[j] != first[j] {
+				t.Fatalf("query %d returned a different order at %d", i, j)
+			}
+		}
+	}
+	for i := range added {
+		if first[i] != added[i] {
+			t.Fatalf("shape %d isn't in the order it was added", i)
+		}
+	}
+}
+
+func TestSpatialHashQueryHugeBounds(t *testing.T) {
+	hash := NewSpatialHash(32)
+	hash.NewRectangleShape(0, 0, 8, 8)
+	hash.NewCircleShape(1000, -1000, 4)
+
+	for _, b := range [][4]float64{
+		{math.Inf(-1), math.Inf(-1), math.Inf(1), math.Inf(1)},
+		{-math.MaxFloat64, -math.MaxFloat64, math.MaxFloat64, math.MaxFloat64},
+		{-1e300, -1e300, 1e300, 1e300},
+	} {
+		if got := hash.Query(b[0], b[1], b[2], b[3]); len(got) != 2 {
+			t.Errorf("Query(%v) returned %d shapes, want 2", b, len(got))
+		}
+	}
+	if got := hash.Query(1e300, 1e300, 1e300, 1e300); len(got) != 0 {
+		t.Errorf("Query far away returned %d shapes, want 0", len(got))
+	}
+}
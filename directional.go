@@ -0,0 +1,205 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Direction is the way a character is facing, in the order of screen angles (y is down)
+type Direction int8
+
+// Directions
+const (
+	DirectionEast Direction = iota
+	DirectionSouthEast
+	DirectionSouth
+	DirectionSouthWest
+	DirectionWest
+	DirectionNorthWest
+	DirectionNorth
+	DirectionNorthEast
+)
+
+// DirectionFromAngle returns the closest direction to angle (radians, 0 is east and Pi/2 is south).
+// directions should be 4 or 8, with 4 only DirectionEast, DirectionSouth, DirectionWest and DirectionNorth are returned.
+// 0 is treated as 8
+func DirectionFromAngle(angle float64, directions int) Direction {
+	if directions <= 0 {
+		directions = 8
+	}
+	step := 2 * math.Pi / float64(directions)
+	i := int(math.Round(angle/step)) % directions
+	if i < 0 {
+		i += directions
+	}
+	return Direction(i * 8 / directions)
+}
+
+// Angle returns the angle the direction faces in radians
+func (d Direction) Angle() float64 {
+	return float64(d) * math.Pi / 4
+}
+
+// DirectionalAnimation plays a different Animation for each direction, e.g. the walk cycle of a top down character
+type DirectionalAnimation struct {
+	Directions int // 4 or 8
+	Animations map[Direction]*Animation
+	Mirrors    map[Direction]Direction // direction -> direction which is drawn flipped horizontally instead
+	Current    Direction
+}
+
+// NewDirectionalAnimation returns a new *DirectionalAnimation for 4 or 8 directions
+func NewDirectionalAnimation(directions int) *DirectionalAnimation {
+	return &DirectionalAnimation{
+		Directions: directions,
+		Animations: make(map[Direction]*Animation),
+		Mirrors:    make(map[Direction]Direction),
+		Current:    DirectionSouth,
+	}
+}
+
+// NewDirectionalAnimationFromRows creates an Animation from each row of the SpriteSheet. rows is the direction of
+// each row from the top, and every sprite in a row is a frame which is drawn for duration
+func NewDirectionalAnimationFromRows(sheet *SpriteSheet, rows []Direction, duration time.Duration) *DirectionalAnimation {
+	d := NewDirectionalAnimation(4)
+	for y, dir := range rows {
+		if dir%2 == 1 {
+			d.Directions = 8
+		}
+		frames := make([]Frame, sheet.SpritesWide)
+		for x := range frames {
+			frames[x] = sheet.NewFrame(x+y*sheet.SpritesWide, duration)
+		}
+		d.SetAnimation(dir, NewAnimation(frames))
+	}
+	return d
+}
+
+// SetAnimation sets the Animation which is played when facing dir
+func (d *DirectionalAnimation) SetAnimation(dir Direction, anim *Animation) *DirectionalAnimation {
+	d.Animations[dir] = anim
+	return d
+}
+
+// SetMirror draws the Animation of source flipped horizontally when facing dir, e.g. DirectionWest from DirectionEast
+func (d *DirectionalAnimation) SetMirror(dir, source Direction) *DirectionalAnimation {
+	d.Mirrors[dir] = source
+	return d
+}
+
+// SetDirection changes the direction. The new Animation carries on from the same frame and time as the old one so the
+// walk cycle doesn't restart
+func (d *DirectionalAnimation) SetDirection(dir Direction) *DirectionalAnimation {
+	if dir == d.Current {
+		return d
+	}
+	prev := d.GetCurrentAnimation()
+	d.Current = dir
+	next := d.GetCurrentAnimation()
+	if prev != nil && prev != next {
+		// the shapes of the previous direction would stay in the hash as stale hitboxes
+		prev.ClearColliders()
+	}
+	if prev != nil && next != nil && prev != next {
+		next.CurrentFrame = prev.CurrentFrame % len(next.Frames)
+		next.CurrentSprite = next.Frames[next.CurrentFrame].Image
+		next.Elapsed = prev.Elapsed
+		next.Cycles = prev.Cycles
+		next.Finished = prev.Finished
+		next.Paused = prev.Paused
+		next.step = prev.step
+		next.started = prev.started
+	}
+	return d
+}
+
+// SetAngle changes the direction to the closest one to angle, see DirectionFromAngle
+func (d *DirectionalAnimation) SetAngle(angle float64) *DirectionalAnimation {
+	return d.SetDirection(DirectionFromAngle(angle, d.Directions))
+}
+
+// SetVector changes the direction to the closest one to v, a zero vector keeps the current direction
+func (d *DirectionalAnimation) SetVector(v *Vector2) *DirectionalAnimation {
+	if v.X == 0 && v.Y == 0 {
+		return d
+	}
+	return d.SetAngle(math.Atan2(v.Y, v.X))
+}
+
+// current returns the Animation of the current direction, and whether it's mirrored
+func (d *DirectionalAnimation) current() (*Animation, bool) {
+	if anim, ok := d.Animations[d.Current]; ok {
+		return anim, false
+	}
+	if source, ok := d.Mirrors[d.Current]; ok {
+		return d.Animations[source], true
+	}
+	return nil, false
+}
+
+// GetCurrentAnimation returns the Animation of the current direction, or of the direction it mirrors
+func (d *DirectionalAnimation) GetCurrentAnimation() *Animation {
+	anim, _ := d.current()
+	return anim
+}
+
+// IsFlipped returns true if the current direction is drawn flipped horizontally, pass it to SyncColliders
+func (d *DirectionalAnimation) IsFlipped() bool {
+	_, flip := d.current()
+	return flip
+}
+
+// GetCurrentSprite returns the current frame of the current direction
+func (d *DirectionalAnimation) GetCurrentSprite() *ebiten.Image {
+	if anim := d.GetCurrentAnimation(); anim != nil {
+		return anim.GetCurrentSprite()
+	}
+	return nil
+}
+
+// Update advances the current Animation by a single tick
+func (d *DirectionalAnimation) Update() {
+	d.UpdateDelta(TickDuration())
+}
+
+// UpdateDelta advances the current Animation by dt
+func (d *DirectionalAnimation) UpdateDelta(dt time.Duration) {
+	if anim := d.GetCurrentAnimation(); anim != nil {
+		anim.UpdateDelta(dt)
+	}
+}
+
+// Draw draws the current Animation, flipped around its pivot (or center) if the direction is a mirror
+func (d *DirectionalAnimation) Draw(surface *ebiten.Image, op *ebiten.DrawImageOptions) {
+	anim, flip := d.current()
+	if anim == nil {
+		return
+	}
+	if !flip {
+		anim.Draw(surface, op)
+		return
+	}
+
+	frame := anim.GetCurrentFrame()
+	flipOp := ebiten.DrawImageOptions{}
+	if op != nil {
+		flipOp = *op
+		flipOp.GeoM.Reset()
+	}
+	if frame.Pivot == nil {
+		// the frame is drawn from its top left, so flip around its center
+		cx := float64(frame.Image.Bounds().Dx()) / 2
+		flipOp.GeoM.Translate(-cx, 0)
+		flipOp.GeoM.Scale(-1, 1)
+		flipOp.GeoM.Translate(cx, 0)
+	} else {
+		flipOp.GeoM.Scale(-1, 1)
+	}
+	if op != nil {
+		flipOp.GeoM.Concat(op.GeoM)
+	}
+	anim.Draw(surface, &flipOp)
+}
@@ -0,0 +1,52 @@
+//go:build ignore
+
+//kage:unit pixels
+
+// Package zen is the root for all ebiten-zen files
+package zen
+
+// the loops cost (MaxThickness*2+1)^2 samples per pixel, so smaller copies of this shader are made by replacing it
+const MaxThickness = 16
+
+var Thickness float
+var OutlineColor vec4
+var Round float
+var Inner float
+var ShadowOffset vec2
+var ShadowColor vec4
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+
+	// how much of the outline covers this pixel
+	edge := 0.0
+	for y := -MaxThickness; y <= MaxThickness; y++ {
+		for x := -MaxThickness; x <= MaxThickness; x++ {
+			o := vec2(float(x), float(y))
+			if abs(o.x) > Thickness || abs(o.y) > Thickness {
+				continue
+			}
+			if Round > 0 && length(o) > Thickness {
+				continue
+			}
+			a := imageSrc0At(srcPos + o).a
+			if Inner > 0 {
+				a = 1 - a
+			}
+			edge = max(edge, a)
+		}
+	}
+
+	outline := vec4(OutlineColor.rgb*OutlineColor.a, OutlineColor.a)
+	var ret vec4
+	if Inner > 0 {
+		ret = mix(c, outline*c.a, edge)
+	} else {
+		ret = c + outline*edge*(1-c.a)
+	}
+
+	shadow := vec4(ShadowColor.rgb*ShadowColor.a, ShadowColor.a) * imageSrc0At(srcPos-ShadowOffset).a
+	ret += shadow * (1 - ret.a)
+
+	return ret * color
+}
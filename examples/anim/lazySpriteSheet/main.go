@@ -0,0 +1,140 @@
+// Package main compares how long NewSpriteSheet and NewLazySpriteSheet take with a big sheet
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+
+	zen "github.com/melonfunction/ebiten-zen"
+)
+
+// vars
+var (
+	WindowWidth  = 640 * 2
+	WindowHeight = 480 * 2
+
+	SheetSize   = 1024 // 4096 sprites
+	SpriteSize  = 16
+	SpritesUsed = 200 // how many sprites are drawn each frame
+	Options     = zen.SpriteSheetOptions{
+		Scale:            4,
+		OutlineThickness: 1,
+		OutlineColor:     color.RGBA{255, 255, 255, 255},
+	}
+
+	Sheet       image.Image
+	SpriteSheet *zen.SpriteSheet
+	used        []int
+
+	// each test is measured from creating the SpriteSheet until the next Update, so that the GPU work is included
+	frame   int
+	started time.Time
+	results []string
+
+	ErrNormalExit = errors.New("Normal exit")
+)
+
+// makeSheet draws random blobs so that every sprite is different
+func makeSheet() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, SheetSize, SheetSize))
+	for y := 0; y < SheetSize; y++ {
+		for x := 0; x < SheetSize; x++ {
+			sx, sy := x%SpriteSize-SpriteSize/2, y%SpriteSize-SpriteSize/2
+			r := SpriteSize/4 + (x/SpriteSize+y/SpriteSize)%(SpriteSize/4)
+			if sx*sx+sy*sy < r*r {
+				img.Set(x, y, color.RGBA{uint8(x), uint8(y), uint8(x ^ y), 255})
+			}
+		}
+	}
+	return img
+}
+
+// Game implements ebiten.Game interface.
+type Game struct{}
+
+// Update proceeds the game state.
+// Update is called every tick (1/60 [s] by default).
+func (g *Game) Update() error {
+	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+		return ErrNormalExit
+	}
+
+	switch frame {
+	case 0:
+		started = time.Now()
+		SpriteSheet = zen.NewSpriteSheet(ebiten.NewImageFromImage(Sheet), SpriteSize, SpriteSize, Options)
+	case 1:
+		b := SpriteSheet.PaddedImage.Bounds()
+		results = append(results, fmt.Sprintf("NewSpriteSheet:     %v, %dx%d padded image",
+			time.Since(started), b.Dx(), b.Dy()))
+		SpriteSheet.PaddedImage.Deallocate()
+
+		started = time.Now()
+		SpriteSheet = zen.NewLazySpriteSheet(Sheet, SpriteSize, SpriteSize, Options)
+	case 2:
+		processed := 0
+		for _, s := range SpriteSheet.Sprites {
+			if s != nil {
+				processed++
+			}
+		}
+		results = append(results, fmt.Sprintf("NewLazySpriteSheet: %v, %d of %d sprites processed",
+			time.Since(started), processed, len(SpriteSheet.Sprites)))
+		for _, r := range results {
+			log.Println(r)
+		}
+	}
+	frame++
+
+	return nil
+}
+
+// Draw draws the game screen.
+// Draw is called every frame (typically 1/60[s] for 60Hz display).
+func (g *Game) Draw(screen *ebiten.Image) {
+	w := SpriteSheet.SpriteWidth
+	for i, s := range used {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(float64(i%(WindowWidth/w)*w), float64(i/(WindowWidth/w)*w))
+		screen.DrawImage(SpriteSheet.GetSpriteByIndex(s), op)
+	}
+
+	msg := ""
+	for _, r := range results {
+		msg += r + "\n"
+	}
+	ebitenutil.DebugPrint(screen, msg)
+}
+
+// Layout sets window size
+func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	WindowWidth = outsideWidth
+	WindowHeight = outsideHeight
+	return outsideWidth, outsideHeight
+}
+
+func main() {
+	game := &Game{}
+	ebiten.SetWindowSize(WindowWidth, WindowHeight)
+	ebiten.SetWindowTitle("Lazy SpriteSheet benchmark")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	ebiten.SetVsyncEnabled(false)
+
+	Sheet = makeSheet()
+	count := (SheetSize / SpriteSize) * (SheetSize / SpriteSize)
+	for i := 0; i < SpritesUsed; i++ {
+		used = append(used, rand.Intn(count))
+	}
+
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
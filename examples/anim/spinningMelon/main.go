@@ -5,12 +5,14 @@ import (
 	"bytes"
 	"embed"
 	"errors"
+	"image/color"
 	"image/png"
 	"log"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 
 	zen "github.com/melonfunction/ebiten-zen"
 )
@@ -28,6 +30,11 @@ var (
 
 	spaceReleased = true
 
+	// turns the green melon into an orange one
+	OrangePalette = zen.NewPalette().
+			Swap(color.RGBA{94, 138, 40, 255}, color.RGBA{168, 80, 24, 255}).
+			Swap(color.RGBA{180, 222, 88, 255}, color.RGBA{240, 144, 48, 255})
+
 	ErrNormalExit = errors.New("Normal exit")
 )
 
@@ -55,6 +62,22 @@ func (g *Game) Update() error {
 		spaceReleased = true
 	}
 
+	// press F to flash red, hold P to swap the palette
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		Animation.Recolor.Flash(color.RGBA{255, 0, 0, 255}, 6)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyP) {
+		Animation.Recolor.SetPalette(OrangePalette)
+	} else {
+		Animation.Recolor.SetPalette(nil)
+	}
+
+	// hold shift for bullet-time
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		Animation.SetSpeed(0.25)
+	} else {
+		Animation.SetSpeed(1)
+	}
 	Animation.Update()
 
 	return nil
@@ -71,9 +94,20 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// Draw the animation in the center of the screen
 	w, h := SpriteSheet.SpriteWidth, SpriteSheet.SpriteHeight
 	op.GeoM.Translate(float64(WindowWidth)/2-float64(w)/2, float64(WindowHeight)/2-float64(h)/2)
-	Animation.Draw(screen, op)
+	if ebiten.IsKeyPressed(ebiten.KeyO) {
+		Animation.DrawOutlined(screen, op, zen.OutlineOptions{
+			Thickness:     4,
+			Color:         color.White,
+			Round:         true,
+			ShadowOffsetX: 8,
+			ShadowOffsetY: 8,
+			ShadowColor:   color.RGBA{0, 0, 0, 128},
+		})
+	} else {
+		Animation.Draw(screen, op)
+	}
 
-	ebitenutil.DebugPrint(screen, "Press space to pause")
+	ebitenutil.DebugPrint(screen, "Press space to pause, F to flash, hold P to swap the palette, hold O to outline")
 }
 
 // Layout sets window size
@@ -108,6 +142,7 @@ func main() {
 				}
 			}
 			Animation = zen.NewAnimation(frames)
+			Animation.Recolor = zen.NewRecolor(nil)
 		}
 	} else {
 		log.Fatal(err)
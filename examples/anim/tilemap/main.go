@@ -0,0 +1,130 @@
+// Package main 👍
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+	"log"
+	"math/rand"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	zen "github.com/melonfunction/ebiten-zen"
+)
+
+//go:embed tiles.png
+var embedded embed.FS
+
+// vars
+var (
+	WindowWidth  = 640 * 2
+	WindowHeight = 480 * 2
+
+	SpriteSheet *zen.SpriteSheet
+	World       *zen.World
+	TileMap     *zen.TileMap
+	cam         *zen.Camera
+
+	ErrNormalExit = errors.New("Normal exit")
+)
+
+// Game implements ebiten.Game interface.
+type Game struct{}
+
+// Update proceeds the game state.
+// Update is called every tick (1/60 [s] by default).
+func (g *Game) Update() error {
+	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+		return ErrNormalExit
+	}
+
+	speed := 8.0
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		cam.MovePosition(-speed, 0)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		cam.MovePosition(speed, 0)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		cam.MovePosition(0, -speed)
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		cam.MovePosition(0, speed)
+	}
+
+	// change the tile under the cursor, only the chunks around it are redrawn
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		x, y := TileMap.GetTileCoords(cam.GetCursorCoords())
+		TileMap.SetSprite(0, x, y, zen.NoSprite)
+	}
+
+	return nil
+}
+
+// Draw draws the game screen.
+// Draw is called every frame (typically 1/60[s] for 60Hz display).
+func (g *Game) Draw(screen *ebiten.Image) {
+	cam.Surface.Clear()
+	cam.Surface.Fill(color.RGBA{32, 32, 32, 255})
+	TileMap.Draw(cam)
+	cam.Blit(screen)
+
+	ebitenutil.DebugPrint(screen, fmt.Sprintf("FPS: %0.2f\nArrow keys to move, click to remove tiles", ebiten.ActualFPS()))
+}
+
+// Layout sets window size
+func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
+	if WindowWidth != outsideWidth || WindowHeight != outsideHeight {
+		cam.Resize(outsideWidth, outsideHeight)
+	}
+	WindowWidth = outsideWidth
+	WindowHeight = outsideHeight
+	return outsideWidth, outsideHeight
+}
+
+func main() {
+	game := &Game{}
+	ebiten.SetWindowSize(WindowWidth, WindowHeight)
+	ebiten.SetWindowTitle("TileMap example")
+	ebiten.SetWindowResizable(true)
+
+	if b, err := embedded.ReadFile("tiles.png"); err == nil {
+		if s, err := png.Decode(bytes.NewReader(b)); err == nil {
+			sprites := ebiten.NewImageFromImage(s)
+			SpriteSheet = zen.NewSpriteSheet(sprites, 8, 8, zen.SpriteSheetOptions{
+				Scale: 2,
+			})
+		}
+	} else {
+		log.Fatal(err)
+	}
+
+	// a huge world with random floors and walls
+	w, h := 1000, 1000
+	World = zen.NewWorld(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if rand.Int()%4 == 0 {
+				World.SetTile(x, y, zen.TileWall)
+			} else {
+				World.SetTile(x, y, zen.TileFloor)
+			}
+		}
+	}
+
+	// every tile is drawn with the same sprite regardless of its neighbors
+	autotiler := zen.NewAutotiler(zen.AutotileMode4Bit)
+	autotiler.SetRule(zen.TileFloor, &zen.AutotileRule{Default: 1 + 1*SpriteSheet.SpritesWide})
+	autotiler.SetRule(zen.TileWall, &zen.AutotileRule{Default: 1 + 3*SpriteSheet.SpritesWide})
+	TileMap = zen.NewTileMapFromWorld(World, autotiler, SpriteSheet, 16)
+
+	cam = zen.NewCamera(WindowWidth, WindowHeight, float64(w*TileMap.TileWidth)/2, float64(h*TileMap.TileHeight)/2, 0, 1)
+
+	if err := ebiten.RunGame(game); err != nil {
+		log.Fatal(err)
+	}
+}
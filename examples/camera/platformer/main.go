@@ -11,6 +11,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 
 	zen "github.com/melonfunction/ebiten-zen"
 )
@@ -22,8 +23,9 @@ var (
 	player *ebiten.Image
 
 	// When keyF is pressed, change follow mode (implemented by using
-	// cam.SetPosition() or cam.MovePosition())
+	// cam.Follow() or cam.MovePosition())
 	CamFollowPlayer = true
+	PlayerCenter    = zen.NewVector2(0, 0) // followed by the camera
 
 	LastWindowWidth  int
 	LastWindowHeight int
@@ -79,6 +81,28 @@ func (g *Game) Update() error {
 	// Keyboard controls
 	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
 		CamFollowPlayer = !CamFollowPlayer
+		if CamFollowPlayer {
+			cam.Follow(PlayerCenter)
+		} else {
+			cam.StopFollowing()
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		cam.AddTrauma(0.5)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+		if _, _, _, _, ok := cam.GetBounds(); ok {
+			cam.ClearBounds()
+		} else {
+			cam.SetBounds(0, 0, float64(TileSize*LevelWidth), float64(TileSize*LevelHeight))
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		if cam.IsPixelPerfect() {
+			cam.DisablePixelPerfect()
+		} else {
+			cam.SetPixelPerfect(320, 240, true)
+		}
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) || ebiten.IsKeyPressed(ebiten.KeyH) {
 		VelX = -5
@@ -90,6 +114,7 @@ func (g *Game) Update() error {
 		if !Jumping {
 			VelY = JumpVel
 			Jumping = true
+			cam.Kick(math.Pi/2, 20) // push the view down a bit
 		}
 	}
 	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
@@ -129,10 +154,10 @@ func (g *Game) Update() error {
 		PlayerX += VelX
 		PlayerY += VelY
 
-		if CamFollowPlayer {
-			cam.SetPosition(PlayerX+float64(PlayerSize)/2, PlayerY+float64(PlayerSize)/2)
-		}
+		PlayerCenter.X = PlayerX + float64(PlayerSize)/2
+		PlayerCenter.Y = PlayerY + float64(PlayerSize)/2
 	}
+	cam.Update(zen.TickDuration())
 
 	// Panning, setting up for click events
 	cx, cy := ebiten.CursorPosition()
@@ -175,9 +200,12 @@ func (g *Game) Update() error {
 	// Zoom
 	_, scrollAmount := ebiten.Wheel()
 	if scrollAmount > 0 {
-		cam.Zoom(1.1)
+		cam.ZoomAt(1.1, float64(cx), float64(cy))
 	} else if scrollAmount < 0 {
-		cam.Zoom(0.9)
+		cam.ZoomAt(0.9, float64(cx), float64(cy))
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyZ) {
+		cam.ZoomTo(1, time.Second/2)
 	}
 
 	return nil
@@ -193,13 +221,14 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			switch Level[y*LevelWidth+x] {
 			case 0:
 			case 1:
-				ebitenutil.DrawRect(
+				vector.FillRect(
 					tiles,
-					float64(x*TileSize),
-					float64(y*TileSize),
-					float64(TileSize),
-					float64(TileSize),
-					color.RGBA{0, 255, 0, 255})
+					float32(x*TileSize),
+					float32(y*TileSize),
+					float32(TileSize),
+					float32(TileSize),
+					color.RGBA{0, 255, 0, 255},
+					false)
 			}
 		}
 	}
@@ -209,9 +238,8 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		player.Fill(color.RGBA{128, 0, 128, 255})
 	}
 
-	// Clear camera surface
+	// Clear camera surface, the sky and hills are parallax layers behind it
 	cam.Surface.Clear()
-	cam.Surface.Fill(color.RGBA{255, 128, 128, 255})
 	// Draw tiles
 	tileOps := &ebiten.DrawImageOptions{}
 	tileOps = cam.GetTranslation(tileOps, 0, 0)
@@ -252,6 +280,28 @@ func main() {
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 
 	cam = zen.NewCamera(w, h, 0, 0, 0, 1)
+	cam.MinZoom, cam.MaxZoom = 0.25, 4
+	cam.Follow(PlayerCenter).
+		SetDeadzone(float64(TileSize), float64(TileSize)*2).
+		SetLookAhead(float64(TileSize)*2, 2)
+	// press B to toggle
+	cam.SetBounds(0, 0, float64(TileSize*LevelWidth), float64(TileSize*LevelHeight))
+
+	// Parallax background
+	sky := ebiten.NewImage(64, 64)
+	sky.Fill(color.RGBA{255, 128, 128, 255})
+	cam.AddParallaxLayer(sky, 0, 0).SetRepeat(true, true)
+	for i, clr := range []color.RGBA{{200, 100, 140, 255}, {150, 80, 130, 255}} {
+		hills := ebiten.NewImage(TileSize*4, TileSize*2)
+		for x := 0; x < TileSize*4; x += TileSize / 4 {
+			top := float32(TileSize) * (1 + 0.6*float32(math.Sin(float64(x+i*TileSize)/float64(TileSize))))
+			vector.FillRect(hills, float32(x), top, float32(TileSize/4), float32(TileSize*2)-top, clr, false)
+		}
+		scroll := 0.25 * float64(i+1)
+		cam.AddParallaxLayer(hills, scroll, scroll).
+			SetOffset(0, float64(TileSize*(LevelHeight-2))*scroll).
+			SetRepeat(true, false)
+	}
 
 	game := &Game{}
 
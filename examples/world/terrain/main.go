@@ -0,0 +1,62 @@
+// Package main
+package main
+
+import (
+	"fmt"
+	"log"
+
+	zen "github.com/melonfunction/ebiten-zen"
+)
+
+// Custom tiles, they only need to be different from the ones zen uses
+const (
+	TileWater zen.Tile = iota + 16
+	TileSand
+	TileGrass
+	TileForest
+	TileMountain
+)
+
+func main() {
+	log.SetFlags(log.Lshortfile)
+
+	w, h := 80, 40
+	world := zen.NewWorld(w, h)
+
+	err := world.GenerateTerrain(1234, zen.TerrainOptions{
+		NoiseType:    zen.NoiseTypeSimplex,
+		Frequency:    0.04,
+		Octaves:      5,
+		WarpStrength: 0.5,
+		Biomes: []zen.Biome{
+			{MaxHeight: 0.4, Tile: TileWater},
+			{MaxHeight: 0.45, Tile: TileSand},
+			{MaxHeight: 0.65, MaxMoisture: 0.5, Tile: TileGrass},
+			{MaxHeight: 0.65, Tile: TileForest},
+			{MaxHeight: 1, Tile: TileMountain},
+		},
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			switch world.Tiles[y][x] {
+			case TileWater:
+				fmt.Print("🟦")
+			case TileSand:
+				fmt.Print("🟨")
+			case TileGrass:
+				fmt.Print("🟩")
+			case TileForest:
+				fmt.Print("🌲")
+			case TileMountain:
+				fmt.Print("⬜")
+			default:
+				fmt.Print(world.Tiles[y][x])
+			}
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,113 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import "fmt"
+
+// FrameColliderType specifies the shape of a FrameCollider
+type FrameColliderType int8
+
+// Collider types
+const (
+	FrameColliderRect FrameColliderType = iota
+	FrameColliderCircle
+)
+
+// FrameCollider is a hitbox which is only active while its Frame is being drawn
+type FrameCollider struct {
+	Name string // used as the key in Animation.Colliders, the index is used if empty and appended to duplicates
+	Type FrameColliderType
+
+	X, Y          float64 // center, relative to the frame's pivot
+	Width, Height float64 // FrameColliderRect only
+	Radius        float64 // FrameColliderCircle only
+}
+
+// NewFrameRectCollider returns a new rectangular FrameCollider
+func NewFrameRectCollider(name string, x, y, w, h float64) FrameCollider {
+	return FrameCollider{
+		Name:   name,
+		Type:   FrameColliderRect,
+		X:      x,
+		Y:      y,
+		Width:  w,
+		Height: h,
+	}
+}
+
+// NewFrameCircleCollider returns a new circular FrameCollider
+func NewFrameCircleCollider(name string, x, y, r float64) FrameCollider {
+	return FrameCollider{
+		Name:   name,
+		Type:   FrameColliderCircle,
+		X:      x,
+		Y:      y,
+		Radius: r,
+	}
+}
+
+// key returns the name of the collider, or i if it doesn't have one. The index is appended to names which are already
+// used so that every collider gets its own shape
+func (c FrameCollider) key(i int, used map[string]Shape) string {
+	key := c.Name
+	if key == "" {
+		key = fmt.Sprint(i)
+	}
+	for {
+		if _, ok := used[key]; !ok {
+			return key
+		}
+		key = fmt.Sprintf("%s.%d", key, i)
+	}
+}
+
+// SyncColliders adds the colliders of the current frame to the hash, positioned at x,y (where the frame's pivot is
+// drawn). Colliders from the previous frame are removed when the frame or scale changes. Call it every tick after
+// Update. scale should match how the frame is drawn, and flipX mirrors the colliders horizontally. The frame's Offset
+// is applied like it is when drawing
+func (a *Animation) SyncColliders(hash *SpatialHash, x, y, scale float64, flipX bool) {
+	if a.colliderHash != hash || a.colliderFrame != a.CurrentFrame || a.colliderScale != scale || a.Colliders == nil {
+		a.ClearColliders()
+		a.colliderHash = hash
+		a.colliderFrame = a.CurrentFrame
+		a.colliderScale = scale
+		a.Colliders = make(map[string]Shape)
+		a.colliderKeys = a.colliderKeys[:0]
+
+		for i, c := range a.Frames[a.CurrentFrame].Colliders {
+			var shape Shape
+			switch c.Type {
+			case FrameColliderRect:
+				shape = hash.NewRectangleShape(0, 0, c.Width*scale, c.Height*scale)
+			case FrameColliderCircle:
+				shape = hash.NewCircleShape(0, 0, c.Radius*scale)
+			}
+			shape.SetParent(a.ColliderParent)
+			key := c.key(i, a.Colliders)
+			a.Colliders[key] = shape
+			a.colliderKeys = append(a.colliderKeys, key)
+		}
+	}
+
+	frame := a.Frames[a.CurrentFrame]
+	var ox, oy float64
+	if frame.Offset != nil {
+		ox, oy = frame.Offset.X, frame.Offset.Y
+	}
+	for i, c := range frame.Colliders {
+		cx := (c.X + ox) * scale
+		if flipX {
+			cx = -cx
+		}
+		a.Colliders[a.colliderKeys[i]].SetPosition(x+cx, y+(c.Y+oy)*scale)
+	}
+}
+
+// ClearColliders removes the shapes which were added by SyncColliders from their hash
+func (a *Animation) ClearColliders() {
+	if a.colliderHash != nil {
+		for _, shape := range a.Colliders {
+			a.colliderHash.Remove(shape)
+		}
+	}
+	a.Colliders = nil
+}
@@ -0,0 +1,109 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NewLazySpriteSheet returns a new SpriteSheet which doesn't create any images until they're used. Each sprite is
+// scaled and outlined on the CPU the first time GetSprite, GetSpriteByIndex or GetSpriteByName returns it, and gets
+// its own image instead of being part of PaddedImage. This uses much less VRAM and starts faster than NewSpriteSheet
+// for big sheets with a large Scale, especially when only some of the sprites are used.
+//
+// Image and PaddedImage are nil, and img is released once every sprite has been processed
+func NewLazySpriteSheet(img image.Image, origSpriteWidth, origSpriteHeight int, options SpriteSheetOptions) *SpriteSheet {
+	options = options.withDefaults()
+	s, _, _, _ := newSpriteSheetRegions(img.Bounds(), origSpriteWidth, origSpriteHeight, options)
+	s.Sprites = make([]*ebiten.Image, len(s.Regions))
+	s.source = img
+	s.remaining = len(s.Regions)
+	return s
+}
+
+// processSprite creates the image of the sprite at index i from the source image
+func (s *SpriteSheet) processSprite(i int) {
+	s.Sprites[i] = ebiten.NewImageFromImage(s.renderSprite(i))
+	s.remaining--
+	if s.remaining == 0 {
+		s.source = nil
+	}
+}
+
+// renderSprite scales and outlines the sprite at index i from the source image on the CPU
+func (s *SpriteSheet) renderSprite(i int) *image.RGBA {
+	src := s.Regions[i].Rect.Add(s.source.Bounds().Min)
+	sc, pad := s.Scale, s.OutlineThickness*s.Scale
+	w, h := src.Dx()*sc+pad*2, src.Dy()*sc+pad*2
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	// scale the sprite up with nearest neighbor
+	for y := 0; y < src.Dy(); y++ {
+		for x := 0; x < src.Dx(); x++ {
+			c := color.RGBAModel.Convert(s.source.At(src.Min.X+x, src.Min.Y+y)).(color.RGBA)
+			if c.A == 0 {
+				continue
+			}
+			for zy := 0; zy < sc; zy++ {
+				for zx := 0; zx < sc; zx++ {
+					dst.SetRGBA(pad+x*sc+zx, pad+y*sc+zy, c)
+				}
+			}
+		}
+	}
+
+	if s.OutlineThickness > 0 {
+		outlineSprite(dst, s.OutlineThickness, s.OutlineColor)
+	}
+	return dst
+}
+
+// outlineSprite draws an outline thickness pixels wide around the opaque pixels of img, behind them
+func outlineSprite(img *image.RGBA, thickness int, c color.RGBA) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	// the outline is the biggest alpha within thickness pixels, dilating horizontally then vertically is the same as
+	// checking the whole square around each pixel
+	alpha := make([]uint8, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			alpha[x+y*w] = img.Pix[img.PixOffset(x, y)+3]
+		}
+	}
+	dilate := func(from []uint8, dx, dy int) []uint8 {
+		to := make([]uint8, len(from))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				var a uint8
+				for z := -thickness; z <= thickness; z++ {
+					nx, ny := x+z*dx, y+z*dy
+					if nx >= 0 && nx < w && ny >= 0 && ny < h && from[nx+ny*w] > a {
+						a = from[nx+ny*w]
+					}
+				}
+				to[x+y*w] = a
+			}
+		}
+		return to
+	}
+	edge := dilate(dilate(alpha, 1, 0), 0, 1)
+
+	// the sprite over the outline, both premultiplied: sprite + outline*(1-spriteAlpha)
+	rgba := [4]uint32{uint32(c.R), uint32(c.G), uint32(c.B), uint32(c.A)}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			o := img.PixOffset(x, y)
+			e := uint32(edge[x+y*w])
+			inv := 0xff - uint32(img.Pix[o+3])
+			if e == 0 || inv == 0 {
+				continue
+			}
+			for ch, v := range rgba {
+				img.Pix[o+ch] += uint8(v * e / 0xff * inv / 0xff)
+			}
+		}
+	}
+}
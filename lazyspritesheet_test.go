@@ -0,0 +1,83 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// makeBenchmarkSheet returns a size*size image of circles, one in each spriteSize*spriteSize sprite
+func makeBenchmarkSheet(size, spriteSize int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			sx, sy := x%spriteSize-spriteSize/2, y%spriteSize-spriteSize/2
+			if sx*sx+sy*sy < spriteSize*spriteSize/9 {
+				img.SetRGBA(x, y, color.RGBA{uint8(x), uint8(y), 128, 255})
+			}
+		}
+	}
+	return img
+}
+
+// TestLazySpriteSheetMatchesEager builds the same sheet on the GPU and on the CPU and compares the outlined sprites
+func TestLazySpriteSheetMatchesEager(t *testing.T) {
+	skipWithoutGPU(t)
+
+	src := makeBenchmarkSheet(32, 16)
+	for _, options := range []SpriteSheetOptions{
+		{Scale: 1},
+		{Scale: 2, OutlineThickness: 1, OutlineColor: color.RGBA{255, 255, 255, 255}},
+		{Scale: 3, OutlineThickness: 2, OutlineColor: color.RGBA{0, 0, 0, 255}},
+	} {
+		eager := NewSpriteSheet(ebiten.NewImageFromImage(src), 16, 16, options)
+		lazy := NewLazySpriteSheet(src, 16, 16, options)
+		for i := range lazy.Regions {
+			want := lazy.renderSprite(i)
+			sprite := eager.GetSpriteByIndex(i)
+			if sprite.Bounds().Size() != want.Bounds().Size() {
+				t.Fatalf("%+v sprite %d: eager size %v, lazy size %v",
+					options, i, sprite.Bounds().Size(), want.Bounds().Size())
+			}
+			got := make([]byte, len(want.Pix))
+			sprite.ReadPixels(got)
+			for p := range got {
+				if got[p] != want.Pix[p] {
+					x, y := p/4%want.Bounds().Dx(), p/4/want.Bounds().Dx()
+					t.Fatalf("%+v sprite %d differs at %d,%d: eager %v, lazy %v",
+						options, i, x, y, got[p&^3:p&^3+4], want.Pix[p&^3:p&^3+4])
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkOutlineSprite(b *testing.B) {
+	src := makeBenchmarkSheet(64, 64)
+	img := image.NewRGBA(src.Bounds())
+	for i := 0; i < b.N; i++ {
+		copy(img.Pix, src.Pix)
+		outlineSprite(img, 2, color.RGBA{255, 255, 255, 255})
+	}
+}
+
+// BenchmarkLazySpriteSheet creates a sheet of 4096 sprites and processes every one of them on the CPU, without
+// uploading them
+func BenchmarkLazySpriteSheet(b *testing.B) {
+	sheet := makeBenchmarkSheet(1024, 16)
+	options := SpriteSheetOptions{
+		Scale:            4,
+		OutlineThickness: 1,
+		OutlineColor:     color.RGBA{255, 255, 255, 255},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s := NewLazySpriteSheet(sheet, 16, 16, options)
+		for j := range s.Regions {
+			s.renderSprite(j)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+var gpuTests = flag.Bool("gpu", false, "run the tests inside a game loop so that images can be read back from the GPU")
+
+// gpu is true while the tests are running inside a game loop
+var gpu bool
+
+// testGame runs the tests in its first Update, since pixels can't be read before the game starts
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *testGame) Update() error {
+	gpu = true
+	g.code = g.m.Run()
+	return ebiten.Termination
+}
+
+func (g *testGame) Draw(screen *ebiten.Image) {}
+
+func (g *testGame) Layout(w, h int) (int, int) {
+	return w, h
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	if *gpuTests {
+		ebiten.SetWindowSize(64, 64)
+		g := &testGame{m: m}
+		if err := ebiten.RunGame(g); err != nil {
+			panic(err)
+		}
+		os.Exit(g.code)
+	}
+	os.Exit(m.Run())
+}
+
+// skipWithoutGPU skips tests which read images back from the GPU unless -gpu is passed
+func skipWithoutGPU(t *testing.T) {
+	if !gpu {
+		t.Skip("needs a GPU, run with -gpu")
+	}
+}
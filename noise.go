@@ -0,0 +1,253 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+var (
+	// ErrNoBiomes is returned when GenerateTerrain is called without any biomes
+	ErrNoBiomes = errors.New("No biomes to generate terrain with")
+)
+
+// NoiseFunc returns the noise value at x,y in the range -1 to 1
+type NoiseFunc func(x, y float64) float64
+
+// Noise generates deterministic 2D noise, the same seed always returns the same values
+type Noise struct {
+	Seed int64
+	perm [512]int
+}
+
+// NewNoise returns a new *Noise
+func NewNoise(seed int64) *Noise {
+	n := &Noise{Seed: seed}
+	p := rand.New(rand.NewSource(seed)).Perm(256)
+	for i := range n.perm {
+		n.perm[i] = p[i&255]
+	}
+	return n
+}
+
+// hash returns a pseudo-random value in the range 0-255 for the lattice point x,y
+func (n *Noise) hash(x, y int) int {
+	return n.perm[n.perm[x&255]+(y&255)]
+}
+
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// gradient returns the dot product of x,y and one of 8 gradients picked by h
+func gradient(h int, x, y float64) float64 {
+	switch h & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
+
+// Value2D returns value noise at x,y in the range -1 to 1
+func (n *Noise) Value2D(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int(x0), int(y0)
+	u, v := fade(x-x0), fade(y-y0)
+
+	value := func(x, y int) float64 {
+		return float64(n.hash(x, y))/127.5 - 1
+	}
+	return lerp(
+		lerp(value(ix, iy), value(ix+1, iy), u),
+		lerp(value(ix, iy+1), value(ix+1, iy+1), u),
+		v)
+}
+
+// Perlin2D returns gradient noise at x,y in the range -1 to 1
+func (n *Noise) Perlin2D(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int(x0), int(y0)
+	fx, fy := x-x0, y-y0
+	u, v := fade(fx), fade(fy)
+
+	return lerp(
+		lerp(gradient(n.hash(ix, iy), fx, fy), gradient(n.hash(ix+1, iy), fx-1, fy), u),
+		lerp(gradient(n.hash(ix, iy+1), fx, fy-1), gradient(n.hash(ix+1, iy+1), fx-1, fy-1), u),
+		v)
+}
+
+// Simplex2D returns simplex noise at x,y in the range -1 to 1. It has fewer directional artifacts than Perlin2D
+func (n *Noise) Simplex2D(x, y float64) float64 {
+	const (
+		f2 = 0.36602540378443864676 // (sqrt(3)-1)/2
+		g2 = 0.21132486540518711775 // (3-sqrt(3))/6
+	)
+
+	// skew into the simplex grid to find which cell x,y is in
+	s := (x + y) * f2
+	i, j := math.Floor(x+s), math.Floor(y+s)
+	t := (i + j) * g2
+	x0, y0 := x-(i-t), y-(j-t)
+
+	// which of the two triangles x,y is in
+	var i1, j1 int
+	if x0 > y0 {
+		i1 = 1
+	} else {
+		j1 = 1
+	}
+	x1, y1 := x0-float64(i1)+g2, y0-float64(j1)+g2
+	x2, y2 := x0-1+2*g2, y0-1+2*g2
+
+	ii, jj := int(i), int(j)
+	corner := func(h int, x, y float64) float64 {
+		t := 0.5 - x*x - y*y
+		if t < 0 {
+			return 0
+		}
+		t *= t
+		return t * t * gradient(h, x, y)
+	}
+
+	return 70 * (corner(n.hash(ii, jj), x0, y0) +
+		corner(n.hash(ii+i1, jj+j1), x1, y1) +
+		corner(n.hash(ii+1, jj+1), x2, y2))
+}
+
+// FBM layers octaves of noise on top of each other (fractal Brownian motion). Each octave's frequency is multiplied by
+// lacunarity and its amplitude by gain. The result is normalized to the range -1 to 1
+func FBM(noise NoiseFunc, x, y float64, octaves int, lacunarity, gain float64) float64 {
+	var sum, total float64
+	amplitude, frequency := 1.0, 1.0
+	for i := 0; i < octaves; i++ {
+		sum += noise(x*frequency, y*frequency) * amplitude
+		total += amplitude
+		amplitude *= gain
+		frequency *= lacunarity
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// DomainWarp offsets x,y by the noise, which makes the noise sampled at the returned coords look swirly.
+// strength is how far the coords can be moved
+func DomainWarp(noise NoiseFunc, x, y, strength float64) (float64, float64) {
+	// sample far away for y so the offsets aren't the same on both axes
+	return x + noise(x, y)*strength, y + noise(x+5.2, y+1.3)*strength
+}
+
+// NoiseType specifies which noise function is used by GenerateTerrain
+type NoiseType int8
+
+// Noise types
+const (
+	NoiseTypeSimplex NoiseType = iota
+	NoiseTypePerlin
+	NoiseTypeValue
+)
+
+// noiseFunc returns the NoiseFunc of the noise type
+func (t NoiseType) noiseFunc(n *Noise) NoiseFunc {
+	switch t {
+	case NoiseTypePerlin:
+		return n.Perlin2D
+	case NoiseTypeValue:
+		return n.Value2D
+	}
+	return n.Simplex2D
+}
+
+// Biome is used by GenerateTerrain to pick a tile depending on the height and moisture at a position
+type Biome struct {
+	MaxHeight   float64 // 0-1
+	MaxMoisture float64 // 0-1, 0 is treated as 1 so it can be ignored
+	Tile        Tile
+}
+
+// TerrainOptions are the options which are passed to the GenerateTerrain function
+type TerrainOptions struct {
+	NoiseType    NoiseType
+	Frequency    float64 // how zoomed in the noise is, smaller values make bigger features
+	Octaves      int
+	Lacunarity   float64
+	Gain         float64
+	WarpStrength float64 // 0 to disable domain warping
+
+	OffsetX, OffsetY int // added to the tile coords, use this to generate chunks of a ChunkedWorld
+
+	// Biomes are checked in order, the first one where the height and moisture are less than or equal to MaxHeight and
+	// MaxMoisture is used. Tiles which don't match any biome are left alone
+	Biomes []Biome
+}
+
+// GenerateTerrain generates the active layer of the world by thresholding noise into biomes.
+// The same seed and options always generate the same world
+func (world *World) GenerateTerrain(seed int64, options TerrainOptions) error {
+	if options.Frequency == 0 {
+		options.Frequency = 0.05
+	}
+	if options.Octaves == 0 {
+		options.Octaves = 4
+	}
+	if options.Lacunarity == 0 {
+		options.Lacunarity = 2
+	}
+	if options.Gain == 0 {
+		options.Gain = 0.5
+	}
+	if len(options.Biomes) == 0 {
+		return ErrNoBiomes
+	}
+
+	heightFunc := options.NoiseType.noiseFunc(NewNoise(seed))
+	moistureFunc := options.NoiseType.noiseFunc(NewNoise(seed + 1))
+	warpFunc := options.NoiseType.noiseFunc(NewNoise(seed + 2))
+
+	sample := func(noise NoiseFunc, x, y float64) float64 {
+		return (FBM(noise, x, y, options.Octaves, options.Lacunarity, options.Gain) + 1) / 2
+	}
+
+	for y := 0; y < world.Height; y++ {
+		for x := 0; x < world.Width; x++ {
+			nx := float64(x+options.OffsetX) * options.Frequency
+			ny := float64(y+options.OffsetY) * options.Frequency
+			if options.WarpStrength != 0 {
+				nx, ny = DomainWarp(warpFunc, nx, ny, options.WarpStrength)
+			}
+
+			height := sample(heightFunc, nx, ny)
+			moisture := sample(moistureFunc, nx, ny)
+			for _, b := range options.Biomes {
+				maxMoisture := b.MaxMoisture
+				if maxMoisture == 0 {
+					maxMoisture = 1
+				}
+				if height <= b.MaxHeight && moisture <= maxMoisture {
+					world.Tiles[y][x] = b.Tile
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed drawoutlinedshader.go
+var drawoutlined_go []byte
+
+// drawOutlinedShaders are copies of the DrawOutlined shader which check up to MaxThickness pixels away, by
+// MaxThickness. Each pixel costs (MaxThickness*2+1)^2 samples, so thin outlines use a smaller copy
+var drawOutlinedShaders = make(map[int]*ebiten.Shader)
+
+// drawOutlinedShaderSizes are the MaxThickness of each copy of the shader
+var drawOutlinedShaderSizes = []int{2, 4, 8, MaxDrawOutlinedThickness}
+
+// MaxDrawOutlinedThickness is the thickest outline DrawOutlined can draw
+const MaxDrawOutlinedThickness = 16
+
+// maxOutlineScratch is how many scratch images DrawOutlined keeps
+const maxOutlineScratch = 8
+
+// outlineScratch stores the padded images used by DrawOutlined so they're only created once. Sizes are rounded up to
+// powers of two and the least recently used one is deallocated when there are more than maxOutlineScratch
+var (
+	outlineScratch     = make(map[image.Point]*outlineScratchImage)
+	outlineScratchUses uint64
+)
+
+// outlineScratchImage is an image in outlineScratch
+type outlineScratchImage struct {
+	image    *ebiten.Image
+	lastUsed uint64
+}
+
+// loadDrawOutlinedShader tries to load the smallest DrawOutlined shader which can draw thickness, panics on error
+func loadDrawOutlinedShader(thickness float64) *ebiten.Shader {
+	size := MaxDrawOutlinedThickness
+	for _, s := range drawOutlinedShaderSizes {
+		if float64(s) >= thickness {
+			size = s
+			break
+		}
+	}
+	if shader, ok := drawOutlinedShaders[size]; ok {
+		return shader
+	}
+
+	src := bytes.Replace(drawoutlined_go,
+		[]byte(fmt.Sprintf("const MaxThickness = %d", MaxDrawOutlinedThickness)),
+		[]byte(fmt.Sprintf("const MaxThickness = %d", size)), 1)
+	shader, err := ebiten.NewShader(src)
+	if err != nil {
+		panic(err)
+	}
+	drawOutlinedShaders[size] = shader
+	return shader
+}
+
+// getOutlineScratch returns a cleared w*h sub image of a scratch image
+func getOutlineScratch(w, h int) *ebiten.Image {
+	size := image.Pt(nextPowerOfTwo(w), nextPowerOfTwo(h))
+	outlineScratchUses++
+	scratch, ok := outlineScratch[size]
+	if !ok {
+		if len(outlineScratch) >= maxOutlineScratch {
+			var oldest image.Point
+			oldestUse := outlineScratchUses
+			for p, s := range outlineScratch {
+				if s.lastUsed < oldestUse {
+					oldest, oldestUse = p, s.lastUsed
+				}
+			}
+			outlineScratch[oldest].image.Deallocate()
+			delete(outlineScratch, oldest)
+		}
+		scratch = &outlineScratchImage{image: ebiten.NewImage(size.X, size.Y)}
+		outlineScratch[size] = scratch
+	}
+	scratch.lastUsed = outlineScratchUses
+
+	img := scratch.image.SubImage(image.Rect(0, 0, w, h)).(*ebiten.Image)
+	img.Clear()
+	return img
+}
+
+// nextPowerOfTwo returns the smallest power of two >= n
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	return p
+}
+
+// OutlineOptions are the options which are passed to the DrawOutlinedWithOptions function
+type OutlineOptions struct {
+	Thickness float64 // in pixels of the source image, up to MaxDrawOutlinedThickness
+	Color     color.Color
+	Round     bool // round corners, otherwise they're square
+	Inner     bool // draw the outline inside the edge of the sprite
+
+	ShadowOffsetX, ShadowOffsetY float64 // how far the drop shadow is moved, both 0 disables it
+	ShadowColor                  color.Color
+}
+
+// DrawOutlined draws src to dst with an outline around it. The GeoM, ColorScale and Blend of op are used
+func DrawOutlined(dst, src *ebiten.Image, op *ebiten.DrawImageOptions, thickness float64, clr color.Color) {
+	DrawOutlinedWithOptions(dst, src, op, OutlineOptions{
+		Thickness: thickness,
+		Color:     clr,
+	})
+}
+
+// DrawOutlinedWithOptions draws src to dst with an outline and/or drop shadow
+func DrawOutlinedWithOptions(dst, src *ebiten.Image, op *ebiten.DrawImageOptions, options OutlineOptions) {
+	drawOutlined(dst, src, op, options, nil)
+}
+
+// drawOutlined draws src into a padded scratch image with recolor if it's not nil, then draws that to dst with the
+// outline shader
+func drawOutlined(dst, src *ebiten.Image, op *ebiten.DrawImageOptions, options OutlineOptions, recolor *Recolor) {
+	thickness := math.Max(0, math.Min(options.Thickness, MaxDrawOutlinedThickness))
+	shader := loadDrawOutlinedShader(thickness)
+	pad := int(math.Ceil(thickness + math.Max(math.Abs(options.ShadowOffsetX), math.Abs(options.ShadowOffsetY))))
+	b := src.Bounds()
+	size := image.Pt(b.Dx()+pad*2, b.Dy()+pad*2)
+
+	scratch := getOutlineScratch(size.X, size.Y)
+	srcOp := &ebiten.DrawImageOptions{}
+	srcOp.GeoM.Translate(float64(pad), float64(pad))
+	if recolor != nil {
+		recolor.Draw(scratch, src, srcOp)
+	} else {
+		scratch.DrawImage(src, srcOp)
+	}
+
+	outlineColor, shadowColor := options.Color, options.ShadowColor
+	if outlineColor == nil || thickness == 0 {
+		outlineColor = color.Transparent
+	}
+	if shadowColor == nil || (options.ShadowOffsetX == 0 && options.ShadowOffsetY == 0) {
+		shadowColor = color.Transparent
+	}
+	round, inner := 0, 0
+	if options.Round {
+		round = 1
+	}
+	if options.Inner {
+		inner = 1
+	}
+
+	sp := &ebiten.DrawRectShaderOptions{}
+	sp.GeoM.Translate(-float64(pad), -float64(pad))
+	if op != nil {
+		sp.GeoM.Concat(op.GeoM)
+		sp.ColorScale = op.ColorScale
+		sp.Blend = op.Blend
+	}
+	sp.Uniforms = map[string]any{
+		"Thickness":    float32(thickness),
+		"OutlineColor": colorToFloats(outlineColor),
+		"Round":        float32(round),
+		"Inner":        float32(inner),
+		"ShadowOffset": []float32{float32(options.ShadowOffsetX), float32(options.ShadowOffsetY)},
+		"ShadowColor":  colorToFloats(shadowColor),
+	}
+	sp.Images[0] = scratch
+	dst.DrawRectShader(size.X, size.Y, shader, sp)
+}
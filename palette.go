@@ -0,0 +1,199 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	_ "embed"
+	"errors"
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed paletteshader.go
+var palette_go []byte
+
+var paletteShader *ebiten.Shader
+
+// MaxPaletteColors is how many colors a Palette can swap
+const MaxPaletteColors = 32
+
+var (
+	// ErrPaletteTooBig is returned when a Palette would have more than MaxPaletteColors colors
+	ErrPaletteTooBig = errors.New("Palette has too many colors")
+)
+
+// loadPaletteShader tries to load the palette shader, panics on error
+func loadPaletteShader() {
+	if paletteShader == nil {
+		shader, err := ebiten.NewShader(palette_go)
+		if err != nil {
+			panic(err)
+		}
+		paletteShader = shader
+	}
+}
+
+// Palette maps colors in a sprite to different colors, so the same sprites can be drawn in different colors
+type Palette struct {
+	Source []color.Color
+	Target []color.Color
+}
+
+// NewPalette returns a new empty *Palette
+func NewPalette() *Palette {
+	return &Palette{
+		Source: make([]color.Color, 0),
+		Target: make([]color.Color, 0),
+	}
+}
+
+// NewPaletteFromImage reads a lookup palette image. The first row of pixels are the colors in the sprites and each row
+// below is a different palette, row 1 is the first one. Fully transparent pixels in the first row are skipped
+func NewPaletteFromImage(img image.Image, row int) (*Palette, error) {
+	b := img.Bounds()
+	if row < 1 || row >= b.Dy() {
+		return nil, ErrOutOfBounds
+	}
+
+	p := NewPalette()
+	for x := b.Min.X; x < b.Max.X; x++ {
+		from := img.At(x, b.Min.Y)
+		if _, _, _, a := from.RGBA(); a == 0 {
+			continue
+		}
+		if len(p.Source) == MaxPaletteColors {
+			return nil, ErrPaletteTooBig
+		}
+		p.Swap(from, img.At(x, b.Min.Y+row))
+	}
+	return p, nil
+}
+
+// Swap replaces from with to, colors past MaxPaletteColors are ignored
+func (p *Palette) Swap(from, to color.Color) *Palette {
+	if len(p.Source) < MaxPaletteColors {
+		p.Source = append(p.Source, from)
+		p.Target = append(p.Target, to)
+	}
+	return p
+}
+
+// Recolor draws sprites with a Palette and/or a solid hit flash color
+type Recolor struct {
+	Palette *Palette // optional
+
+	FlashColor  color.Color
+	FlashAmount float64 // 0-1, how much of the flash color is used, 1 is solid
+	FlashFrames int     // how many more Updates the flash is drawn for
+
+	uniforms map[string]any
+	palette  *Palette // the Palette that the uniforms were built for
+	count    int      // how many colors it had
+	source   []float32
+	target   []float32
+	flash    []float32
+}
+
+// NewRecolor returns a new *Recolor, palette can be nil if only hit flashes are needed
+func NewRecolor(palette *Palette) *Recolor {
+	loadPaletteShader()
+	r := &Recolor{
+		Palette:     palette,
+		FlashColor:  color.White,
+		FlashAmount: 1,
+		uniforms:    make(map[string]any),
+		source:      make([]float32, MaxPaletteColors*4),
+		target:      make([]float32, MaxPaletteColors*4),
+		flash:       make([]float32, 4),
+	}
+	r.buildPalette()
+	return r
+}
+
+// SetPalette changes the Palette, nil draws the original colors. Call it again after changing the colors of the current
+// Palette directly, colors added with Swap are picked up automatically
+func (r *Recolor) SetPalette(palette *Palette) *Recolor {
+	r.Palette = palette
+	r.buildPalette()
+	return r
+}
+
+// buildPalette converts the Palette's colors for the shader
+func (r *Recolor) buildPalette() {
+	r.palette = r.Palette
+	r.count = 0
+	if r.Palette != nil {
+		r.count = minInt(len(r.Palette.Source), MaxPaletteColors)
+	}
+	for i := 0; i < r.count; i++ {
+		setColorFloats(r.source[i*4:], r.Palette.Source[i])
+		setColorFloats(r.target[i*4:], r.Palette.Target[i])
+	}
+	clear(r.source[r.count*4:])
+	clear(r.target[r.count*4:])
+	r.uniforms["SourceColors"] = r.source
+	r.uniforms["TargetColors"] = r.target
+	r.uniforms["ColorCount"] = r.count
+}
+
+// Flash tints the sprites to a solid color for the given number of frames
+func (r *Recolor) Flash(c color.Color, frames int) *Recolor {
+	r.FlashColor = c
+	r.FlashFrames = frames
+	return r
+}
+
+// IsFlashing returns true while the hit flash is drawn
+func (r *Recolor) IsFlashing() bool {
+	return r.FlashFrames > 0
+}
+
+// Update counts down the hit flash, call it once per tick. Animation calls it for you while it isn't paused
+func (r *Recolor) Update() {
+	if r.FlashFrames > 0 {
+		r.FlashFrames--
+	}
+}
+
+// Draw draws src to dst using the palette and hit flash. The GeoM, ColorScale and Blend of op are used
+func (r *Recolor) Draw(dst, src *ebiten.Image, op *ebiten.DrawImageOptions) {
+	if (r.Palette == nil || len(r.Palette.Source) == 0) && !r.IsFlashing() {
+		dst.DrawImage(src, op)
+		return
+	}
+
+	if r.Palette != r.palette || (r.Palette != nil && minInt(len(r.Palette.Source), MaxPaletteColors) != r.count) {
+		r.buildPalette()
+	}
+	setColorFloats(r.flash, r.FlashColor)
+	r.uniforms["FlashColor"] = r.flash
+	if r.IsFlashing() {
+		r.uniforms["FlashAmount"] = float32(r.FlashAmount)
+	} else {
+		r.uniforms["FlashAmount"] = float32(0)
+	}
+
+	sp := &ebiten.DrawRectShaderOptions{}
+	if op != nil {
+		sp.GeoM = op.GeoM
+		sp.ColorScale = op.ColorScale
+		sp.Blend = op.Blend
+	}
+	sp.Uniforms = r.uniforms
+	sp.Images[0] = src
+	dst.DrawRectShader(src.Bounds().Dx(), src.Bounds().Dy(), paletteShader, sp)
+}
+
+// colorToFloats returns the non-premultiplied color in the range 0-1
+func colorToFloats(c color.Color) []float32 {
+	f := make([]float32, 4)
+	setColorFloats(f, c)
+	return f
+}
+
+// setColorFloats writes the non-premultiplied color in the range 0-1 to the first 4 floats of dst
+func setColorFloats(dst []float32, c color.Color) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	dst[0], dst[1], dst[2], dst[3] = float32(n.R)/255, float32(n.G)/255, float32(n.B)/255, float32(n.A)/255
+}
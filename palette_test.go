@@ -0,0 +1,37 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image/color"
+	"testing"
+	"time"
+)
+
+func TestRecolorBuildPalette(t *testing.T) {
+	r := NewRecolor(nil)
+	p := NewPalette().Swap(color.White, color.Black)
+	r.SetPalette(p)
+	if r.count != 1 || r.source[0] != 1 || r.target[0] != 0 || r.target[3] != 1 {
+		t.Fatalf("palette wasn't built: %v %v", r.source[:4], r.target[:4])
+	}
+
+	r.SetPalette(nil)
+	if r.count != 0 || r.source[0] != 0 {
+		t.Fatalf("palette wasn't cleared: %v", r.source[:4])
+	}
+}
+
+func TestRecolorFlashPaused(t *testing.T) {
+	a := NewAnimation(testFrames(2, time.Second))
+	a.Recolor = NewRecolor(nil).Flash(color.White, 2)
+	a.Paused = true
+	a.UpdateDelta(time.Second)
+	if a.Recolor.FlashFrames != 2 {
+		t.Fatalf("flash counted down while paused: %d", a.Recolor.FlashFrames)
+	}
+	a.Paused = false
+	a.UpdateDelta(time.Second)
+	if a.Recolor.FlashFrames != 1 {
+		t.Fatalf("got %d flash frames, want 1", a.Recolor.FlashFrames)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build ignore
+
+//kage:unit pixels
+
+// Package zen is the root for all ebiten-zen files
+package zen
+
+// the size of the arrays has to match MaxPaletteColors
+var SourceColors [32]vec4
+var TargetColors [32]vec4
+var ColorCount int
+var FlashColor vec4
+var FlashAmount float
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+	if c.a == 0 {
+		return vec4(0)
+	}
+
+	// palette colors aren't premultiplied
+	rgb := c.rgb / c.a
+	for i := 0; i < 32; i++ {
+		if i >= ColorCount {
+			break
+		}
+		if distance(rgb, SourceColors[i].rgb) < 0.5/255.0 {
+			rgb = TargetColors[i].rgb
+			c.a *= TargetColors[i].a
+			break
+		}
+	}
+
+	rgb = mix(rgb, FlashColor.rgb, FlashAmount*FlashColor.a)
+	return vec4(rgb*c.a, c.a) * color
+}
@@ -0,0 +1,134 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ParallaxLayer is an image which Camera.Blit draws behind the Surface, moving slower or faster than the world to make
+// it look further away or closer. Clear the Surface instead of filling it so that the layers can be seen
+type ParallaxLayer struct {
+	Image            *ebiten.Image
+	X, Y             float64 // where the top left of the Image is when the camera is at 0,0
+	ScrollX, ScrollY float64 // how far it moves compared to the world, 0 stays still on the screen and 1 moves with it
+	Zoom             float64 // how much the camera's zoom affects it, 0 not at all and 1 the same as the world
+	RepeatX, RepeatY bool    // tile the Image to fill the screen
+	Hidden           bool
+}
+
+// AddParallaxLayer adds a layer in front of the existing ones and returns it so it can be configured. Its Zoom is the
+// average of scrollX and scrollY, so layers which are further away zoom less
+func (c *Camera) AddParallaxLayer(img *ebiten.Image, scrollX, scrollY float64) *ParallaxLayer {
+	l := &ParallaxLayer{
+		Image:   img,
+		ScrollX: scrollX,
+		ScrollY: scrollY,
+		Zoom:    (scrollX + scrollY) / 2,
+	}
+	c.Parallax = append(c.Parallax, l)
+	return l
+}
+
+// RemoveParallaxLayer removes the layer from the camera
+func (c *Camera) RemoveParallaxLayer(layer *ParallaxLayer) *Camera {
+	for i, l := range c.Parallax {
+		if l == layer {
+			c.Parallax = append(c.Parallax[:i], c.Parallax[i+1:]...)
+			break
+		}
+	}
+	return c
+}
+
+// ClearParallaxLayers removes every layer from the camera
+func (c *Camera) ClearParallaxLayers() *Camera {
+	c.Parallax = nil
+	return c
+}
+
+// SetOffset sets where the top left of the Image is when the camera is at 0,0
+func (l *ParallaxLayer) SetOffset(x, y float64) *ParallaxLayer {
+	l.X = x
+	l.Y = y
+	return l
+}
+
+// SetScroll sets how far the layer moves compared to the world
+func (l *ParallaxLayer) SetScroll(x, y float64) *ParallaxLayer {
+	l.ScrollX = x
+	l.ScrollY = y
+	return l
+}
+
+// SetZoom sets how much the camera's zoom affects the layer
+func (l *ParallaxLayer) SetZoom(zoom float64) *ParallaxLayer {
+	l.Zoom = zoom
+	return l
+}
+
+// SetRepeat tiles the Image horizontally and/or vertically
+func (l *ParallaxLayer) SetRepeat(x, y bool) *ParallaxLayer {
+	l.RepeatX = x
+	l.RepeatY = y
+	return l
+}
+
+// drawParallax draws the layers to dst. Each one is positioned in Surface pixels, then scaled by scale and transformed
+// by screen like the Surface is
+func (c *Camera) drawParallax(dst *ebiten.Image, screen ebiten.GeoM, scale, rotation float64) {
+	if len(c.Parallax) == 0 {
+		return
+	}
+
+	// half of the size of the rotated view, in Surface pixels
+	w, h := float64(c.Width), float64(c.Height)
+	co, si := math.Abs(math.Cos(rotation)), math.Abs(math.Sin(rotation))
+	ex, ey := (w*co+h*si)/2, (w*si+h*co)/2
+
+	// keep pixel perfect layers on whole pixels, the same way as the Surface
+	snap := func(v float64) float64 {
+		switch {
+		case c.pixel == nil:
+			return v
+		case c.pixel.subpixel:
+			return math.Round(v * scale)
+		}
+		return math.Ceil(v) * scale
+	}
+
+	for _, l := range c.Parallax {
+		if l.Image == nil || l.Hidden {
+			continue
+		}
+		zoom := math.Pow(c.Scale, l.Zoom)
+		iw := float64(l.Image.Bounds().Dx()) * zoom
+		ih := float64(l.Image.Bounds().Dy()) * zoom
+		x := (l.X-c.Position.X*l.ScrollX)*zoom + w/2
+		y := (l.Y-c.Position.Y*l.ScrollY)*zoom + h/2
+
+		for _, ty := range parallaxTiles(y, ih, h/2-ey, h/2+ey, l.RepeatY) {
+			for _, tx := range parallaxTiles(x, iw, w/2-ex, w/2+ex, l.RepeatX) {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Scale(zoom*scale, zoom*scale)
+				op.GeoM.Translate(snap(tx), snap(ty))
+				op.GeoM.Concat(screen)
+				dst.DrawImage(l.Image, op)
+			}
+		}
+	}
+}
+
+// parallaxTiles returns where each copy of an image which is size long starts along one axis, so that min to max is
+// covered. Without repeat it's only drawn at pos
+func parallaxTiles(pos, size, min, max float64, repeat bool) []float64 {
+	if !repeat || size <= 0 {
+		return []float64{pos}
+	}
+	var tiles []float64
+	for p := pos - math.Ceil((pos-min)/size)*size; p < max; p += size {
+		tiles = append(tiles, p)
+	}
+	return tiles
+}
@@ -28,6 +28,12 @@ func loadOutlineShader() {
 	}
 }
 
+// cullRadius returns how far from its position a drawable using img can be drawn, it's bigger than needed so that
+// rotation doesn't have to be taken into account
+func cullRadius(img *ebiten.Image, rotationPoint *Vector2) float64 {
+	return float64(img.Bounds().Dx()+img.Bounds().Dy()) + rotationPoint.Length()
+}
+
 // IsometricDrawable is an interface that Wall and Floor must satisfy
 type IsometricDrawable interface {
 	Draw(camera *Camera)
@@ -133,6 +139,9 @@ func NewBillboard(sprite *ebiten.Image, position, rotationPointOffset *Vector2)
 func (s *Billboard) Draw(camera *Camera) {
 	worldRotationPoint := camera.Position
 	s.RotatedPos = s.Position.RotateAround(camera.WorldRotation, worldRotationPoint)
+	if !camera.isNear(s.RotatedPos, cullRadius(s.internalImage, s.RotationPoint)) {
+		return
+	}
 
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(-s.RotationPoint.X, -s.RotationPoint.Y)
@@ -206,6 +215,9 @@ func (s *SpriteStack) Draw(camera *Camera) {
 
 	worldRotationPoint := camera.Position
 	s.RotatedPos = s.Position.Sub(s.RotationPoint).Rotate(s.Rotation).RotateAround(camera.WorldRotation, worldRotationPoint)
+	if !camera.isNear(s.RotatedPos, cullRadius(s.internalImage, s.RotationPoint)) {
+		return
+	}
 
 	if s.OutlineThickness > 0 {
 		s.internalImage.Clear()
@@ -225,9 +237,15 @@ func (s *SpriteStack) Draw(camera *Camera) {
 		op = camera.GetTranslation(op, s.RotatedPos.X, s.RotatedPos.Y)
 	}
 
+	// each layer is drawn a little higher than the one below it, the translation already includes the zoom when it's
+	// drawn straight to the camera
+	layerOffset := math.Min(-1, -float64(s.SpriteSheet.Scale)+0.5)
+	if !(s.OutlineThickness > 0) {
+		layerOffset *= camera.Scale
+	}
 	for i := s.SpriteSheet.SpritesHigh - 1; i >= 0; i-- {
 		sprite := s.SpriteSheet.GetSprite(0, i)
-		op.GeoM.Translate(0, math.Min(-1, -float64(s.SpriteSheet.Scale)+0.5))
+		op.GeoM.Translate(0, layerOffset)
 		if s.OutlineThickness > 0 {
 			s.internalImage.DrawImage(sprite, op)
 		} else {
@@ -287,6 +305,9 @@ func (s *Floor) Draw(camera *Camera) {
 	rotation = math.Atan2(math.Sin(rotation), math.Cos(rotation))
 	worldRotationPoint := camera.Position
 	s.RotatedPos = s.Position.RotateAround(camera.WorldRotation, worldRotationPoint)
+	if !camera.isNear(s.RotatedPos, cullRadius(s.internalImage, s.RotationPoint)) {
+		return
+	}
 
 	op := &ebiten.DrawImageOptions{}
 	op.GeoM.Translate(-s.RotationPoint.X, -s.RotationPoint.Y)
@@ -360,6 +381,9 @@ func (s *Wall) Draw(camera *Camera) {
 	rotation = math.Atan2(math.Sin(rotation), math.Cos(rotation))
 	worldRotationPoint := camera.Position
 	s.RotatedPos = s.Position.RotateAround(camera.WorldRotation, worldRotationPoint)
+	if !camera.isNear(s.RotatedPos, cullRadius(s.internalImage, s.RotationPoint)) {
+		return
+	}
 
 	if s.OutlineThickness > 0 {
 		s.internalImage.Clear()
@@ -0,0 +1,133 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SplitScreen gives each player their own Camera and viewport for local co-op, and merges them into one Camera which
+// shows everyone while the players are close together
+type SplitScreen struct {
+	Cameras []*Camera  // one per target, used while split
+	Targets []*Vector2 // usually the positions of the players
+	Shared  *Camera    // used while merged, follows the middle of the targets
+
+	Width, Height int     // size of the screen
+	MergeDistance float64 // merge when every target is closer than this to the middle, 0 never merges
+	Split         bool
+
+	middle *Vector2
+}
+
+// NewSplitScreen returns a new *SplitScreen for up to 4 targets. The cameras follow their targets using the default
+// follow settings, use Camera.Follow on them to change them
+func NewSplitScreen(width, height int, mergeDistance float64, targets ...*Vector2) *SplitScreen {
+	s := &SplitScreen{
+		Targets:       targets,
+		MergeDistance: mergeDistance,
+		middle:        NewVector2(0, 0),
+	}
+	s.updateMiddle()
+	for _, t := range targets {
+		c := NewCamera(width, height, t.X, t.Y, 0, 1)
+		c.Follow(t)
+		s.Cameras = append(s.Cameras, c)
+	}
+	s.Shared = NewCamera(width, height, s.middle.X, s.middle.Y, 0, 1)
+	s.Shared.Follow(s.middle)
+	s.Split = mergeDistance <= 0 || !s.canMerge(mergeDistance)
+	return s.Resize(width, height)
+}
+
+// Resize changes the size of the screen and lays out the viewports. 2 players are side by side, 3 or 4 are in a grid
+func (s *SplitScreen) Resize(width, height int) *SplitScreen {
+	s.Width, s.Height = width, height
+	s.Shared.SetViewport(0, 0, width, height)
+
+	n := len(s.Cameras)
+	switch {
+	case n == 1:
+		s.Cameras[0].SetViewport(0, 0, width, height)
+	case n == 2:
+		s.Cameras[0].SetViewport(0, 0, width/2, height)
+		s.Cameras[1].SetViewport(width/2, 0, width-width/2, height)
+	case n > 2:
+		w, h := width/2, height/2
+		for i, c := range s.Cameras {
+			x, y := i%2*w, i/2%2*h
+			c.SetViewport(x, y, w+i%2*(width-w*2), h+i/2%2*(height-h*2))
+		}
+	}
+	return s
+}
+
+// updateMiddle moves middle to the average position of the targets
+func (s *SplitScreen) updateMiddle() {
+	if len(s.Targets) == 0 {
+		return
+	}
+	var x, y float64
+	for _, t := range s.Targets {
+		x += t.X
+		y += t.Y
+	}
+	s.middle.X = x / float64(len(s.Targets))
+	s.middle.Y = y / float64(len(s.Targets))
+}
+
+// canMerge returns true if every target is within distance of the middle
+func (s *SplitScreen) canMerge(distance float64) bool {
+	for _, t := range s.Targets {
+		if t.Sub(s.middle).Length() > distance {
+			return false
+		}
+	}
+	return true
+}
+
+// Update splits or merges the screen, then updates the cameras which are being used
+func (s *SplitScreen) Update(dt time.Duration) {
+	s.updateMiddle()
+
+	if s.MergeDistance > 0 {
+		// split a bit further away than merging so it doesn't flicker at the edge
+		if s.Split && s.canMerge(s.MergeDistance) {
+			// start from where the players were looking so the view doesn't jump
+			var x, y float64
+			for _, c := range s.Cameras {
+				x += c.Position.X
+				y += c.Position.Y
+			}
+			s.Shared.SetPosition(x/float64(len(s.Cameras)), y/float64(len(s.Cameras)))
+			s.Split = false
+		} else if !s.Split && !s.canMerge(s.MergeDistance*1.1) {
+			for _, c := range s.Cameras {
+				c.SetPosition(s.Shared.Position.X, s.Shared.Position.Y)
+			}
+			s.Split = true
+		}
+	}
+
+	for _, c := range s.GetCameras() {
+		c.Update(dt)
+	}
+}
+
+// GetCameras returns the cameras which are currently being used
+func (s *SplitScreen) GetCameras() []*Camera {
+	if s.Split {
+		return s.Cameras
+	}
+	return []*Camera{s.Shared}
+}
+
+// Draw clears each camera's Surface, calls draw so the world can be drawn to it, then blits it to the screen
+func (s *SplitScreen) Draw(screen *ebiten.Image, draw func(camera *Camera)) {
+	for _, c := range s.GetCameras() {
+		c.Surface.Clear()
+		draw(c)
+		c.Blit(screen)
+	}
+}
@@ -0,0 +1,109 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// SpriteDrawOptions are the options which are passed to SpriteSheet.DrawSprite
+type SpriteDrawOptions struct {
+	FlipX, FlipY   bool        // mirror the sprite around its pivot
+	Rotation       float64     // radians, around the pivot
+	ScaleX, ScaleY float64     // 0 is treated as 1
+	Tint           color.Color // optional, multiplies the color of the sprite
+}
+
+// DrawSprite draws the sprite at index i so that its pivot is at the origin of op, see SpriteSheet.GetPivot.
+// op can be nil, or come from Camera.GetTranslation
+func (s *SpriteSheet) DrawSprite(dst *ebiten.Image, i int, op *ebiten.DrawImageOptions, options SpriteDrawOptions) {
+	sprite := s.GetSpriteByIndex(i)
+	if sprite == nil {
+		return
+	}
+
+	sx, sy := options.ScaleX, options.ScaleY
+	if sx == 0 {
+		sx = 1
+	}
+	if sy == 0 {
+		sy = 1
+	}
+	if options.FlipX {
+		sx = -sx
+	}
+	if options.FlipY {
+		sy = -sy
+	}
+
+	pivot := s.GetPivot(i)
+	spriteOp := &ebiten.DrawImageOptions{}
+	spriteOp.GeoM.Translate(-pivot.X, -pivot.Y)
+	spriteOp.GeoM.Scale(sx, sy)
+	spriteOp.GeoM.Rotate(options.Rotation)
+	if op != nil {
+		spriteOp.GeoM.Concat(op.GeoM)
+		spriteOp.ColorScale = op.ColorScale
+		spriteOp.Filter = op.Filter
+		spriteOp.Blend = op.Blend
+	}
+	if options.Tint != nil {
+		spriteOp.ColorScale.ScaleWithColor(options.Tint)
+	}
+	dst.DrawImage(sprite, spriteOp)
+}
+
+// DrawNineSlice draws the sprite at index i stretched to width*height, with its top left corner at the origin of op.
+// left, top, right and bottom are the size of the border in pixels of the original image, the corners aren't stretched
+// and the edges are only stretched along their length. The outline of the sprite isn't drawn
+func (s *SpriteSheet) DrawNineSlice(dst *ebiten.Image, i int, op *ebiten.DrawImageOptions, width, height float64, left, top, right, bottom int) {
+	sprite := s.GetSpriteByIndex(i)
+	if sprite == nil || width <= 0 || height <= 0 {
+		return
+	}
+
+	// skip the outline around the sprite
+	ot := s.OutlineThickness * s.Scale
+	r := sprite.Bounds().Inset(ot)
+	srcX := []int{0, left * s.Scale, r.Dx() - right*s.Scale, r.Dx()}
+	srcY := []int{0, top * s.Scale, r.Dy() - bottom*s.Scale, r.Dy()}
+	if srcX[1] > srcX[2] || srcY[1] > srcY[2] {
+		return
+	}
+	dstX := nineSliceCuts(float64(srcX[1]), float64(r.Dx()-srcX[2]), width)
+	dstY := nineSliceCuts(float64(srcY[1]), float64(r.Dy()-srcY[2]), height)
+
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			sw, sh := srcX[x+1]-srcX[x], srcY[y+1]-srcY[y]
+			dw, dh := dstX[x+1]-dstX[x], dstY[y+1]-dstY[y]
+			if sw <= 0 || sh <= 0 || dw <= 0 || dh <= 0 {
+				continue
+			}
+
+			sliceOp := &ebiten.DrawImageOptions{}
+			sliceOp.GeoM.Scale(dw/float64(sw), dh/float64(sh))
+			sliceOp.GeoM.Translate(dstX[x], dstY[y])
+			if op != nil {
+				sliceOp.GeoM.Concat(op.GeoM)
+				sliceOp.ColorScale = op.ColorScale
+				sliceOp.Filter = op.Filter
+				sliceOp.Blend = op.Blend
+			}
+			src := image.Rect(srcX[x], srcY[y], srcX[x+1], srcY[y+1]).Add(r.Min)
+			dst.DrawImage(sprite.SubImage(src).(*ebiten.Image), sliceOp)
+		}
+	}
+}
+
+// nineSliceCuts returns where the slices start and end along one axis, the borders shrink if size is smaller than them
+func nineSliceCuts(start, end, size float64) []float64 {
+	if start+end > size {
+		ratio := size / (start + end)
+		start *= ratio
+		end *= ratio
+	}
+	return []float64{0, start, size - end, size}
+}
@@ -0,0 +1,213 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TileMap draws TileLayers to a Camera. Tiles are rendered into chunks which are cached until they're invalidated, and
+// only the chunks which are visible to the Camera are drawn
+type TileMap struct {
+	Layers []*TileLayer // drawn in order
+
+	Width, Height         int // how many tiles are in the map
+	TileWidth, TileHeight int // how big each tile is in pixels
+	ChunkSize             int // how many tiles wide and high each chunk is, 0 is treated as 16
+	MaxCachedChunks       int // chunks which weren't drawn recently are deallocated past this amount
+
+	chunks map[CellCoord]*tileMapChunk
+	frame  int
+}
+
+// tileMapChunk stores a pre-rendered area of the TileMap
+type tileMapChunk struct {
+	image     *ebiten.Image
+	dirty     bool
+	lastDrawn int
+}
+
+// ErrNoTileLayers is returned when a TileMap is created without any layers
+var ErrNoTileLayers = errors.New("TileMap needs at least one TileLayer")
+
+// NewTileMap returns a new *TileMap. The tile size is taken from the first layer's SpriteSheet
+func NewTileMap(layers []*TileLayer, chunkSize int) (*TileMap, error) {
+	if len(layers) == 0 {
+		return nil, ErrNoTileLayers
+	}
+	m := &TileMap{
+		Layers:          layers,
+		TileWidth:       layers[0].SpriteSheet.OrigSpriteWidth * layers[0].SpriteSheet.Scale,
+		TileHeight:      layers[0].SpriteSheet.OrigSpriteHeight * layers[0].SpriteSheet.Scale,
+		ChunkSize:       chunkSize,
+		MaxCachedChunks: 256,
+		chunks:          make(map[CellCoord]*tileMapChunk),
+	}
+	for _, l := range layers {
+		m.Width = maxInt(m.Width, l.Width)
+		m.Height = maxInt(m.Height, l.Height)
+	}
+	return m, nil
+}
+
+// NewTileMapFromWorld autotiles the world and returns a new *TileMap with a single layer
+func NewTileMapFromWorld(world *World, autotiler *Autotiler, spriteSheet *SpriteSheet, chunkSize int) *TileMap {
+	m, _ := NewTileMap([]*TileLayer{autotiler.Autotile(world, spriteSheet)}, chunkSize) // there's always one layer
+	return m
+}
+
+// getChunkSize returns ChunkSize, or 16 if it isn't set
+func (m *TileMap) getChunkSize() int {
+	if m.ChunkSize <= 0 {
+		return 16
+	}
+	return m.ChunkSize
+}
+
+// SetSprite sets the sprite index at x,y in the layer and invalidates the chunk it's in
+func (m *TileMap) SetSprite(layer, x, y, i int) error {
+	if layer < 0 || layer >= len(m.Layers) {
+		return ErrOutOfBounds
+	}
+	if err := m.Layers[layer].SetSprite(x, y, i); err != nil {
+		return err
+	}
+	m.Invalidate(x, y)
+	return nil
+}
+
+// Invalidate marks the chunk containing the tile at x,y to be redrawn. Neighboring chunks are also invalidated if the
+// tile is on the edge of its chunk, since outlines can overlap them
+func (m *TileMap) Invalidate(x, y int) {
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			c := m.chunkCoord(x+dx, y+dy)
+			if chunk, ok := m.chunks[c]; ok {
+				chunk.dirty = true
+			}
+		}
+	}
+}
+
+// InvalidateAll marks every chunk to be redrawn. Use this after changing Layers directly
+func (m *TileMap) InvalidateAll() {
+	for _, chunk := range m.chunks {
+		chunk.dirty = true
+	}
+}
+
+// Deallocate deallocates all cached chunks
+func (m *TileMap) Deallocate() {
+	for c, chunk := range m.chunks {
+		chunk.image.Deallocate()
+		delete(m.chunks, c)
+	}
+}
+
+// chunkCoord returns the coord of the chunk containing the tile at x,y
+func (m *TileMap) chunkCoord(x, y int) CellCoord {
+	size := float64(m.getChunkSize())
+	return CellCoord{
+		int(math.Floor(float64(x) / size)),
+		int(math.Floor(float64(y) / size)),
+	}
+}
+
+// Draw draws the visible chunks to the camera's Surface
+func (m *TileMap) Draw(camera *Camera) {
+	m.frame++
+
+	x1, y1, x2, y2 := camera.GetVisibleBounds()
+
+	size := m.getChunkSize()
+	cw := float64(m.TileWidth * size)
+	ch := float64(m.TileHeight * size)
+	maxX := (m.Width - 1) / size
+	maxY := (m.Height - 1) / size
+	minCX := maxInt(0, int(math.Floor(x1/cw)))
+	minCY := maxInt(0, int(math.Floor(y1/ch)))
+	maxCX := minInt(maxX, int(math.Floor(x2/cw)))
+	maxCY := minInt(maxY, int(math.Floor(y2/ch)))
+
+	for cy := minCY; cy <= maxCY; cy++ {
+		for cx := minCX; cx <= maxCX; cx++ {
+			c := CellCoord{cx, cy}
+			chunk, ok := m.chunks[c]
+			if !ok {
+				chunk = &tileMapChunk{
+					image: ebiten.NewImage(int(cw), int(ch)),
+					dirty: true,
+				}
+				m.chunks[c] = chunk
+			}
+			if chunk.dirty {
+				m.renderChunk(c, chunk)
+			}
+			chunk.lastDrawn = m.frame
+
+			op := &ebiten.DrawImageOptions{}
+			op = camera.GetTranslation(op, float64(cx)*cw, float64(cy)*ch)
+			camera.Surface.DrawImage(chunk.image, op)
+		}
+	}
+
+	m.evictChunks()
+}
+
+// renderChunk redraws every layer into the chunk's image
+func (m *TileMap) renderChunk(c CellCoord, chunk *tileMapChunk) {
+	chunk.image.Clear()
+	size := m.getChunkSize()
+	ox := c.X * size
+	oy := c.Y * size
+
+	for _, l := range m.Layers {
+		ot := float64(l.SpriteSheet.OutlineThickness * l.SpriteSheet.Scale)
+		// draw an extra tile around the chunk so outlines from neighboring chunks aren't cut off
+		for y := oy - 1; y <= oy+size; y++ {
+			for x := ox - 1; x <= ox+size; x++ {
+				i, err := l.GetSprite(x, y)
+				if err != nil || i == NoSprite {
+					continue
+				}
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(
+					float64((x-ox)*m.TileWidth)-ot,
+					float64((y-oy)*m.TileHeight)-ot)
+				chunk.image.DrawImage(l.SpriteSheet.GetSpriteByIndex(i), op)
+			}
+		}
+	}
+	chunk.dirty = false
+}
+
+// evictChunks deallocates the chunks which were drawn least recently until there are MaxCachedChunks left
+func (m *TileMap) evictChunks() {
+	if len(m.chunks) <= m.MaxCachedChunks {
+		return
+	}
+
+	// chunks which were drawn this frame are visible, so they're kept
+	var old []CellCoord
+	for c, chunk := range m.chunks {
+		if chunk.lastDrawn < m.frame {
+			old = append(old, c)
+		}
+	}
+	sort.Slice(old, func(i, j int) bool {
+		return m.chunks[old[i]].lastDrawn < m.chunks[old[j]].lastDrawn
+	})
+
+	for _, c := range old[:minInt(len(old), len(m.chunks)-m.MaxCachedChunks)] {
+		m.chunks[c].image.Deallocate()
+		delete(m.chunks, c)
+	}
+}
+
+// GetTileCoords converts world coords into tile coords
+func (m *TileMap) GetTileCoords(x, y float64) (int, int) {
+	return int(math.Floor(x / float64(m.TileWidth))), int(math.Floor(y / float64(m.TileHeight)))
+}
@@ -0,0 +1,20 @@
+// Package zen is the root for all ebiten-zen files
+package zen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewTileMapNoLayers(t *testing.T) {
+	if _, err := NewTileMap(nil, 16); !errors.Is(err, ErrNoTileLayers) {
+		t.Fatalf("got %v, want ErrNoTileLayers", err)
+	}
+}
+
+func TestTileMapChunkSizeDefault(t *testing.T) {
+	m := &TileMap{}
+	if got := m.chunkCoord(17, -1); got != (CellCoord{1, -1}) {
+		t.Fatalf("got %v, want {1 -1}", got)
+	}
+}
@@ -62,12 +62,27 @@ func (t Tile) String() string {
 	return "🚧"
 }
 
+// Layer names which are commonly used. Any name can be used with AddLayer
+const (
+	LayerGround     = "ground"
+	LayerDecoration = "decoration"
+	LayerObjects    = "objects"
+	LayerOverlay    = "overlay"
+)
+
 // World represents the map, Tiles are stored in [y][x] order, but GetTile can be used with (x,y) order to simplify some
 // processes
+//
+// A World can have multiple named layers. Tiles always refers to the ActiveLayer, so GetTile, SetTile and the
+// generators read and write to whichever layer is active. The LayerGround layer is active by default
 type World struct {
 	Width, Height int
 
-	Tiles [][]Tile // indexed [y][x]
+	Tiles       [][]Tile // indexed [y][x], the tiles of the ActiveLayer
+	Layers      map[string][][]Tile
+	LayerOrder  []string // names of the layers in the order they were added
+	ActiveLayer string
+
 	Rooms map[Rect]struct{}
 	Doors map[Rect]DoorDirection
 
@@ -88,10 +103,11 @@ type World struct {
 	MinRoomWidth              int
 	MinRoomHeight             int
 	MinIslandSize             int // RandomWalk only; any TileVoid islands < this are filled with TileFloor
+
+	rng *rand.Rand
 }
 
 var (
-	rng *rand.Rand
 	// ErrOutOfBounds is returned when a tile is attempted to be placed out of bounds
 	ErrOutOfBounds = errors.New("Coordinate out of bounds")
 	// ErrNotEnoughSpace is returned when there isn't enough space to generate the world
@@ -100,25 +116,99 @@ var (
 	ErrGenerationTimeout = errors.New("Took too long to generate world")
 	// ErrFloorAlreadyPlaced is returned when a floor tile is already placed
 	ErrFloorAlreadyPlaced = errors.New("Floor tile already placed")
+	// ErrLayerNotFound is returned when a layer doesn't exist
+	ErrLayerNotFound = errors.New("Layer not found")
+	// ErrLayerExists is returned when a layer is added with a name that is already used
+	ErrLayerExists = errors.New("Layer already exists")
+	// ErrLayerActive is returned when the active layer is removed
+	ErrLayerActive = errors.New("Can't remove the active layer")
 )
 
-// Reset clears the tiles from the world
-func (world *World) Reset(width, height int) {
+func newTiles(width, height int) [][]Tile {
 	tiles := make([][]Tile, height)
 	for i := range tiles {
 		tiles[i] = make([]Tile, width)
 	}
-	world.Tiles = tiles
+	return tiles
+}
+
+// Reset clears the tiles from the active layer. Other layers are only cleared if the size of the world changes
+func (world *World) Reset(width, height int) {
+	if world.Layers == nil {
+		world.Layers = make(map[string][][]Tile)
+	}
+	if world.ActiveLayer == "" {
+		world.ActiveLayer = LayerGround
+	}
+	if _, ok := world.Layers[world.ActiveLayer]; !ok {
+		world.LayerOrder = append(world.LayerOrder, world.ActiveLayer)
+	}
+	for name, tiles := range world.Layers {
+		if len(tiles) != height || (height > 0 && len(tiles[0]) != width) {
+			world.Layers[name] = newTiles(width, height)
+		}
+	}
+	world.Tiles = newTiles(width, height)
+	world.Layers[world.ActiveLayer] = world.Tiles
 
 	world.Rooms = make(map[Rect]struct{})
 	world.Doors = make(map[Rect]DoorDirection)
 }
 
+// AddLayer adds an empty layer to the world
+func (world *World) AddLayer(name string) error {
+	if _, ok := world.Layers[name]; ok {
+		return ErrLayerExists
+	}
+	world.Layers[name] = newTiles(world.Width, world.Height)
+	world.LayerOrder = append(world.LayerOrder, name)
+	return nil
+}
+
+// RemoveLayer removes a layer from the world. The active layer can't be removed
+func (world *World) RemoveLayer(name string) error {
+	if _, ok := world.Layers[name]; !ok {
+		return ErrLayerNotFound
+	}
+	if name == world.ActiveLayer {
+		return ErrLayerActive
+	}
+	delete(world.Layers, name)
+	for i, n := range world.LayerOrder {
+		if n == name {
+			world.LayerOrder = append(world.LayerOrder[:i], world.LayerOrder[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// SetActiveLayer changes which layer Tiles, GetTile, SetTile and the generators use
+func (world *World) SetActiveLayer(name string) error {
+	tiles, ok := world.Layers[name]
+	if !ok {
+		return ErrLayerNotFound
+	}
+	world.Layers[world.ActiveLayer] = world.Tiles // in case Tiles was replaced
+	world.ActiveLayer = name
+	world.Tiles = tiles
+	return nil
+}
+
+// GetLayer returns the tiles of a layer, indexed [y][x]
+func (world *World) GetLayer(name string) ([][]Tile, error) {
+	if name == world.ActiveLayer {
+		return world.Tiles, nil
+	}
+	tiles, ok := world.Layers[name]
+	if !ok {
+		return nil, ErrLayerNotFound
+	}
+	return tiles, nil
+}
+
 // NewWorld returns a new world instance
 func NewWorld(width, height int) *World {
-	s1 := rand.NewSource(time.Now().UnixNano())
-	rng = rand.New(s1)
-
 	world := &World{
 		Width:  width,
 		Height: height,
@@ -139,11 +229,20 @@ func NewWorld(width, height int) *World {
 		MinRoomWidth:              4,
 		MinRoomHeight:             4,
 		MinIslandSize:             26,
+
+		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	world.Reset(width, height)
 	return world
 }
 
+// SetSeed seeds the world's random number generator which is used by the generators. NewWorld seeds it with the
+// current time, so call this to generate the same world every time
+func (world *World) SetSeed(seed int64) *World {
+	world.rng = rand.New(rand.NewSource(seed))
+	return world
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
@@ -162,27 +261,53 @@ func absInt(a int) int {
 	}
 	return a
 }
-func randInt(a, b int) int {
-	return rng.Int()%(b+1-a) + a
+func (world *World) randInt(a, b int) int {
+	return world.rng.Int()%(b+1-a) + a
 }
 
-// GetTile returns a tile
+// GetTile returns a tile from the active layer
 func (world *World) GetTile(x, y int) (Tile, error) {
+	return world.getTile(world.Tiles, x, y)
+}
+
+// SetTile sets a tile in the active layer
+func (world *World) SetTile(x, y int, t Tile) error {
+	return world.setTile(world.Tiles, x, y, t)
+}
+
+// GetLayerTile returns a tile from the named layer
+func (world *World) GetLayerTile(layer string, x, y int) (Tile, error) {
+	tiles, err := world.GetLayer(layer)
+	if err != nil {
+		return TileVoid, err
+	}
+	return world.getTile(tiles, x, y)
+}
+
+// SetLayerTile sets a tile in the named layer
+func (world *World) SetLayerTile(layer string, x, y int, t Tile) error {
+	tiles, err := world.GetLayer(layer)
+	if err != nil {
+		return err
+	}
+	return world.setTile(tiles, x, y, t)
+}
+
+func (world *World) getTile(tiles [][]Tile, x, y int) (Tile, error) {
 	w, h, b := world.Width, world.Height, world.Border
 	if x >= w-b || x < 0+b || y >= h-b || y < 0+b {
 		return TileVoid, ErrOutOfBounds
 	}
-	return world.Tiles[y][x], nil
+	return tiles[y][x], nil
 }
 
-// SetTile sets a tile
-func (world *World) SetTile(x, y int, t Tile) error {
+func (world *World) setTile(tiles [][]Tile, x, y int, t Tile) error {
 	w, h, b := world.Width, world.Height, world.Border
 	if t == TileFloor && (x >= w-b || x < 0+b || y >= h-b || y < 0+b) {
 		return ErrOutOfBounds
 	}
 
-	world.Tiles[y][x] = t
+	tiles[y][x] = t
 	return nil
 }
 
@@ -334,7 +459,7 @@ func (world *World) GenerateRandomWalk(tileCount int) error {
 				return g()
 			}
 
-			switch rng.Int() % 8 {
+			switch world.rng.Int() % 8 {
 			case 0:
 				dx = -1
 				dy = 0
@@ -353,7 +478,7 @@ func (world *World) GenerateRandomWalk(tileCount int) error {
 			x += dx
 			y += dy
 
-			cs := randInt(world.MinDoorSize, world.MaxDoorSize)
+			cs := world.randInt(world.MinDoorSize, world.MaxDoorSize)
 			for tx := x - cs/2; tx < x+cs/2; tx++ {
 				for ty := y - cs/2; ty < y+cs/2; ty++ {
 					tc++
@@ -455,7 +580,7 @@ func (world *World) GenerateDungeonGrid(roomCount int) error {
 				}
 				return g()
 			}
-			switch rng.Int() % 4 {
+			switch world.rng.Int() % 4 {
 			case 0:
 				sx--
 			case 1:
@@ -536,13 +661,13 @@ func (world *World) GenerateDungeonGrid(roomCount int) error {
 				y1 := prev.Y*s - world.MaxRoomWidth/2
 				y2 := cur.Y*s - world.MaxRoomWidth/2
 				cd := DoorDirectionHorizontal
-				cs := randInt(world.MinDoorSize, world.MaxDoorSize)
+				cs := world.randInt(world.MinDoorSize, world.MaxDoorSize)
 				var offsetCy, offsetCx int
 				if world.AllowRandomCorridorOffset {
 					offsetCy = (world.MaxRoomWidth - cs)
-					offsetCy = randInt(-offsetCy/2, offsetCy/2)
+					offsetCy = world.randInt(-offsetCy/2, offsetCy/2)
 					offsetCx = (world.MaxRoomWidth - cs)
-					offsetCx = randInt(-offsetCx/2, offsetCx/2)
+					offsetCx = world.randInt(-offsetCx/2, offsetCx/2)
 				}
 				switch {
 				case dx == -1: // left
@@ -663,8 +788,8 @@ func (world *World) GenerateDungeon(roomCount int) error {
 
 		// Random first room size
 		sx, sy := world.Width/2, world.Height/2
-		rw := randInt(world.MinRoomWidth, world.MaxRoomWidth)
-		rh := randInt(world.MinRoomHeight, world.MaxRoomHeight)
+		rw := world.randInt(world.MinRoomWidth, world.MaxRoomWidth)
+		rh := world.randInt(world.MinRoomHeight, world.MaxRoomHeight)
 
 		// Place the first room into the world
 		placeRoom(sx, sy, rw, rh)
@@ -687,20 +812,20 @@ func (world *World) GenerateDungeon(roomCount int) error {
 			osy := sy
 			orw := rw
 			orh := rh
-			rw = randInt(world.MinRoomWidth, world.MaxRoomWidth)
-			rh = randInt(world.MinRoomHeight, world.MaxRoomHeight)
+			rw = world.randInt(world.MinRoomWidth, world.MaxRoomWidth)
+			rh = world.randInt(world.MinRoomHeight, world.MaxRoomHeight)
 			cx, cy := osx, osy // corridor position
-			cs := randInt(world.MinDoorSize, world.MaxDoorSize)
+			cs := world.randInt(world.MinDoorSize, world.MaxDoorSize)
 			var cw, ch int
 			var offsetCy, offsetCx int
 			if world.AllowRandomCorridorOffset {
 				offsetCy = (minInt(rh, orh) - ch)
-				offsetCy = randInt(-cs/2, offsetCy/2-cs/2)
+				offsetCy = world.randInt(-cs/2, offsetCy/2-cs/2)
 				offsetCx = (minInt(rw, orw) - cw)
-				offsetCx = randInt(-cs/2, offsetCx/2-cs/2)
+				offsetCx = world.randInt(-cs/2, offsetCx/2-cs/2)
 			}
 			cd := DoorDirectionHorizontal
-			switch rng.Int() % 4 {
+			switch world.rng.Int() % 4 {
 			case 0: // left
 				cw = world.WallThickness
 				ch = cs
@@ -733,7 +858,7 @@ func (world *World) GenerateDungeon(roomCount int) error {
 				if world.ShowErrorMessages {
 					log.Println("rollback:", err, sx, sy, rw, rh)
 				}
-				c := previousRooms[rng.Int()%len(previousRooms)]
+				c := previousRooms[world.rng.Int()%len(previousRooms)]
 				sx = c.X
 				sy = c.Y
 				rw = c.W